@@ -0,0 +1,256 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hyperproperties/gorrupt/pkg/fi"
+	"github.com/hyperproperties/gorrupt/pkg/fi/tester"
+)
+
+// The runner of the CLI where the input and output types of the package are only known at runtime.
+// The inputs are given as JSON and the outputs are decoded into their JSON objects.
+type runner = tester.Runner[json.RawMessage, output]
+
+type execution = tester.Execution[json.RawMessage, output]
+
+// The decoded JSON object of an output. It is detected if its detector field is true.
+type output map[string]any
+
+// The field of the outputs which reports detection by a countermeasure (if any).
+var detector string
+
+func (output output) Detected() bool {
+	detected, ok := output[detector].(bool)
+	return ok && detected
+}
+
+// The flag values which can be given multiple times.
+type list []string
+
+func (list *list) String() string {
+	return strings.Join(*list, ",")
+}
+
+func (list *list) Set(value string) error {
+	*list = append(*list, value)
+	return nil
+}
+
+// The campaign of a command from its flags and the campaign file.
+type campaign struct {
+	tester.Campaign
+
+	flags *flag.FlagSet
+	// The names of the flags given on the command line.
+	given map[string]bool
+
+	config  string
+	inputs  list
+	timeout time.Duration
+	batch   int
+
+	// The flags of the commands.
+	allowed  list
+	outcomes list
+	plan     string
+	format   string
+	output   string
+	root     string
+}
+
+func newCampaign(name string, stderr io.Writer) *campaign {
+	campaign := &campaign{
+		flags: flag.NewFlagSet("gorrupt "+name, flag.ContinueOnError),
+		given: make(map[string]bool),
+	}
+	campaign.flags.SetOutput(stderr)
+
+	flags := campaign.flags
+	flags.StringVar(&campaign.config, "config", "", "the YAML or JSON campaign `file` whose fields are overridden by the flags")
+	flags.StringVar(&campaign.QEMU, "qemu", "", "the `path` of the qemu with fault injection ($"+tester.QEMUEnvironment+" or "+tester.QEMUName+" in the PATH by default)")
+	flags.StringVar(&campaign.Package, "package", "", "the import `path` of the package under attack")
+	flags.StringVar(&campaign.Name, "name", "", "the `name` of the package if it is not the last element of its path")
+	flags.StringVar(&campaign.Input, "type", "", "the `name` of the input type in the package with the method Call")
+	flags.Var((*list)(&campaign.Functions), "function", "a `function` of the package under attack (repeatable)")
+	flags.Var((*list)(&campaign.Searchers), "model", "a fault `model`: "+strings.Join(tester.Searchers, ", ")+" (repeatable)")
+	flags.Var((*list)(&campaign.Masks), "mask", "a mask `strategy`, e.g., \"hamming 2\" (repeatable, those of the searchers by default)")
+	flags.Var(&campaign.inputs, "input", "a JSON encoded `input` (repeatable, {} by default)")
+	flags.Var((*list)(&campaign.Environment), "env", "a `KEY=VALUE` of the build environment (repeatable, GOARCH=arm and GOOS=linux by default)")
+	flags.StringVar(&campaign.Directory, "directory", ".gorrupt", "the `directory` of the generated and built files")
+	flags.DurationVar(&campaign.timeout, "timeout", time.Minute, "the timeout of an execution")
+	flags.IntVar(&campaign.Order, "order", 1, "the number of attacks in an attack plan")
+	flags.StringVar(&campaign.Store, "store", tester.StoreName, "the `name` of the store of executions in the directory (none if empty)")
+	flags.StringVar(&campaign.Detector, "detector", "", "the boolean `field` of the output which is true if a countermeasure detected a fault")
+
+	return campaign
+}
+
+// Parses the flags and then the campaign file for the flags which were not given.
+func (campaign *campaign) parse(arguments []string) error {
+	if err := campaign.flags.Parse(arguments); err != nil {
+		return err
+	}
+	campaign.flags.Visit(func(flag *flag.Flag) {
+		campaign.given[flag.Name] = true
+	})
+
+	campaign.Timeout = campaign.timeout
+	campaign.Batch = int32(campaign.batch)
+	for _, input := range campaign.inputs {
+		if !json.Valid([]byte(input)) {
+			return fmt.Errorf("input %q is not valid JSON", input)
+		}
+		campaign.Inputs = append(campaign.Inputs, json.RawMessage(input))
+	}
+
+	if campaign.config != "" {
+		if err := campaign.load(campaign.config); err != nil {
+			return err
+		}
+	}
+
+	if len(campaign.Inputs) == 0 {
+		campaign.Inputs = []any{json.RawMessage("{}")}
+	}
+	detector = campaign.Detector
+
+	return nil
+}
+
+// Sets the fields from the campaign file unless their flags were given on the command line.
+func (campaign *campaign) load(name string) error {
+	file, err := tester.LoadCampaign(name)
+	if err != nil {
+		return err
+	}
+
+	// The fields of the file which are empty keep the defaults of their flags.
+	from := func(flag string, empty bool) bool {
+		return !campaign.given[flag] && !empty && campaign.flags.Lookup(flag) != nil
+	}
+	if from("qemu", file.QEMU == "") {
+		campaign.QEMU = file.QEMU
+	}
+	if from("package", file.Package == "") {
+		campaign.Package = file.Package
+	}
+	if from("name", file.Name == "") {
+		campaign.Name = file.Name
+	}
+	if from("type", file.Input == "") {
+		campaign.Input = file.Input
+	}
+	if from("function", len(file.Functions) == 0) {
+		campaign.Functions = file.Functions
+	}
+	if from("model", len(file.Searchers) == 0) {
+		campaign.Searchers = file.Searchers
+	}
+	if from("mask", len(file.Masks) == 0) {
+		campaign.Masks = file.Masks
+	}
+	if from("input", len(file.Inputs) == 0) {
+		campaign.Inputs = file.Inputs
+	}
+	if from("env", len(file.Environment) == 0) {
+		campaign.Environment = file.Environment
+	}
+	if from("directory", file.Directory == "") {
+		campaign.Directory = file.Directory
+	}
+	if from("timeout", file.Timeout == 0) {
+		campaign.Timeout = file.Timeout
+	}
+	if from("order", file.Order == 0) {
+		campaign.Order = file.Order
+	}
+	if from("store", file.Store == "") {
+		campaign.Store = file.Store
+	}
+	if from("detector", file.Detector == "") {
+		campaign.Detector = file.Detector
+	}
+	if from("allow", len(file.Allow) == 0) {
+		campaign.Allow = file.Allow
+	}
+	if from("parallel", file.Pool == 0) {
+		campaign.Pool = file.Pool
+	}
+	if from("batch", file.Batch == 0) {
+		campaign.Batch = file.Batch
+	}
+	campaign.Reports = file.Reports
+
+	return nil
+}
+
+func (campaign *campaign) runner() (*runner, error) {
+	if err := campaign.Validate(); err != nil {
+		return nil, err
+	}
+	return tester.CampaignRunner[json.RawMessage, output](campaign.Campaign)
+}
+
+// The options of every quantifier configuration of the campaign which creates the directory.
+func (campaign *campaign) options() ([]tester.QuantifierOption, error) {
+	if err := os.MkdirAll(campaign.Directory, os.ModePerm); err != nil {
+		return nil, err
+	}
+
+	options := []tester.QuantifierOption{
+		tester.WithDirectory(campaign.Directory),
+		tester.WithTimeout(campaign.Timeout),
+	}
+	if campaign.Store != "" {
+		options = append(options, tester.WithStore(campaign.Store))
+	}
+
+	return options, nil
+}
+
+// The quantifier configuration of the campaign which creates the directory.
+func (campaign *campaign) configuration(options ...tester.QuantifierOption) (tester.QuantifierConfiguration, error) {
+	if err := os.MkdirAll(campaign.Directory, os.ModePerm); err != nil {
+		return tester.QuantifierConfiguration{}, err
+	}
+	return campaign.Configuration(options...)
+}
+
+// Prepares the configuration for the first input and computes its targets.
+func (campaign *campaign) prepare(ctx context.Context) (*runner, tester.QuantifierConfiguration, []fi.Target, error) {
+	runner, err := campaign.runner()
+	if err != nil {
+		return nil, tester.QuantifierConfiguration{}, nil, err
+	}
+
+	configuration, err := campaign.configuration()
+	if err != nil {
+		return nil, configuration, nil, err
+	}
+
+	inputs, err := campaign.EncodedInputs()
+	if err != nil {
+		return nil, configuration, nil, err
+	}
+
+	if err := runner.Prepare(ctx, inputs[0], &configuration); err != nil {
+		return nil, configuration, nil, err
+	}
+	if err := campaign.CheckFunctions(configuration.Dump()); err != nil {
+		return nil, configuration, nil, err
+	}
+
+	var targets []fi.Target
+	for _, option := range configuration.Targets() {
+		targets = append(targets, option(configuration.Dump())...)
+	}
+
+	return runner, configuration, targets, nil
+}
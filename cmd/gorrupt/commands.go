@@ -0,0 +1,309 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"github.com/hyperproperties/gorrupt/pkg/fi"
+	"github.com/hyperproperties/gorrupt/pkg/fi/tester"
+	"github.com/hyperproperties/gorrupt/pkg/report"
+)
+
+var _ fi.TagetVisitor = (*describer)(nil)
+
+// Describes a target as a line of its fault model followed by its location.
+type describer struct {
+	description string
+}
+
+func describe(target fi.Target) string {
+	var describer describer
+	target.Visit(&describer)
+	return describer.description
+}
+
+func (describer *describer) BFR(target fi.BFRTarget) {
+	describer.description = fmt.Sprintf("bfr R%d 0x%x 0x%x", target.Register(), target.Source(), target.Destination())
+}
+
+func (describer *describer) BFM(target fi.BFMTarget) {
+	describer.description = fmt.Sprintf("bfm 0x%x %d 0x%x 0x%x", target.Address(), target.Width(), target.Source(), target.Destination())
+}
+
+func (describer *describer) SAR(target fi.SARTarget) {
+	describer.description = fmt.Sprintf("sar R%d 0x%x 0x%x", target.Register(), target.Source(), target.Destination())
+}
+
+func (describer *describer) SR(target fi.SRTarget) {
+	describer.description = fmt.Sprintf("sr R%d 0x%x 0x%x", target.Register(), target.Source(), target.Destination())
+}
+
+func (describer *describer) IS(target fi.ISTarget) {
+	describer.description = fmt.Sprintf("is 0x%x", target.PC())
+}
+
+func (describer *describer) IC(target fi.ICTarget) {
+	describer.description = fmt.Sprintf("ic 0x%x", target.PC())
+}
+
+func listTargets(ctx context.Context, campaign *campaign, arguments []string, stdout io.Writer) error {
+	_, _, targets, err := campaign.prepare(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, target := range targets {
+		fmt.Fprintln(stdout, describe(target))
+	}
+
+	return nil
+}
+
+func listPlans(ctx context.Context, campaign *campaign, arguments []string, stdout io.Writer) error {
+	_, configuration, targets, err := campaign.prepare(ctx)
+	if err != nil {
+		return err
+	}
+
+	planner := fi.NewAttackPlanner(configuration.PlannerOptions()...)
+	for _, plan := range planner.Plan(targets...) {
+		fmt.Fprintln(stdout, plan)
+	}
+
+	return nil
+}
+
+func defineRun(campaign *campaign) {
+	campaign.flags.Var(&campaign.allowed, "allow", "an `outcome` satisfying the property (repeatable, golden and detected by default)")
+	campaign.flags.IntVar(&campaign.Pool, "parallel", 0, "the number of parallel executions (sequential if 0)")
+	campaign.flags.IntVar(&campaign.batch, "batch", 4096, "the number of parallel executions between checks for termination")
+}
+
+func runCampaign(ctx context.Context, campaign *campaign, arguments []string, stdout io.Writer) error {
+	// The binary of the first input is prepared to check that the functions exist.
+	runner, _, _, err := campaign.prepare(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Every violation is reported.
+	configuration, err := campaign.configuration(tester.WithLimit(-1))
+	if err != nil {
+		return err
+	}
+
+	inputs, err := campaign.EncodedInputs()
+	if err != nil {
+		return err
+	}
+
+	allowed := campaign.Allow
+	if len(campaign.allowed) > 0 || len(allowed) == 0 {
+		if allowed, err = outcomes(campaign.allowed, tester.GoldenOutcome, tester.DetectedOutcome); err != nil {
+			return err
+		}
+	}
+	property := func(execution execution) (bool, error) {
+		return slices.Contains(allowed, execution.Outcome), nil
+	}
+
+	var result tester.Result[json.RawMessage, output]
+	if campaign.Pool > 0 {
+		result, err = runner.ForallParallel(ctx, configuration.Parallel(
+			tester.WithPool(campaign.Pool), tester.WithBatch(campaign.Batch),
+		), slices.All(inputs), property)
+	} else {
+		result, err = runner.Forall(ctx, configuration, slices.All(inputs), property)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, execution := range result.Executions {
+		fmt.Fprintf(stdout, "%s %s %s\n", execution.Outcome, execution.Plan, execution.Input)
+	}
+	if !result.Holds {
+		return fmt.Errorf("%w by %d executions", errViolated, len(result.Executions))
+	}
+
+	return nil
+}
+
+func defineReplay(campaign *campaign) {
+	campaign.flags.StringVar(&campaign.plan, "plan", "", "the attack `plan` to replay, e.g., \"[bfr 10 0 0xdc3d4 0xdc3d8 8192]\"")
+}
+
+func replayPlan(ctx context.Context, campaign *campaign, arguments []string, stdout io.Writer) error {
+	plan, err := fi.ParseAttackPlan(campaign.plan)
+	if err != nil {
+		return err
+	}
+
+	runner, err := campaign.runner()
+	if err != nil {
+		return err
+	}
+
+	inputs, err := campaign.EncodedInputs()
+	if err != nil {
+		return err
+	}
+
+	options, err := campaign.options()
+	if err != nil {
+		return err
+	}
+	configuration := tester.NewQuantifierConfiguration(options...)
+
+	for _, input := range inputs {
+		replay, err := runner.Replay(ctx, configuration, input, plan)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(stdout, replay)
+	}
+
+	return nil
+}
+
+func defineReport(campaign *campaign) {
+	campaign.flags.StringVar(&campaign.format, "format", "terminal", "the `format` of the report: jsonl, csv, sarif, terminal, or html")
+	campaign.flags.Var(&campaign.outcomes, "outcome", "an `outcome` of the reported findings (repeatable, all but golden by default)")
+	campaign.flags.StringVar(&campaign.output, "output", "", "the `file` of the report (standard output if empty)")
+	campaign.flags.StringVar(&campaign.root, "root", "", "the root `directory` of the relative locations of SARIF reports (the module of the package by default)")
+}
+
+func writeReport(ctx context.Context, campaign *campaign, arguments []string, stdout io.Writer) error {
+	if campaign.Store == "" {
+		return errors.New("the store is required")
+	}
+
+	runner, err := campaign.runner()
+	if err != nil {
+		return err
+	}
+
+	inputs, err := campaign.EncodedInputs()
+	if err != nil {
+		return err
+	}
+
+	selected, err := outcomes(campaign.outcomes,
+		tester.CorruptionOutcome, tester.DetectedOutcome, tester.CrashOutcome,
+		tester.TimeoutOutcome, tester.EmulatorOutcome, tester.UnparsableOutcome)
+	if err != nil {
+		return err
+	}
+
+	// The binaries are only built to locate the attacks in the source.
+	options, err := campaign.options()
+	if err != nil {
+		return err
+	}
+	base := tester.NewQuantifierConfiguration(options...)
+
+	store, err := tester.OpenStore(base.StorePath())
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	// Every input has its own binary whose records are located in its dump.
+	var findings []report.Finding
+	for _, input := range inputs {
+		configuration := base
+		if err := runner.Prepare(ctx, input, &configuration); err != nil {
+			return err
+		}
+
+		found, err := report.FromStore(*configuration.Dump(), configuration.Hash(), store, selected...)
+		if err != nil {
+			return err
+		}
+		findings = append(findings, found...)
+	}
+
+	// The reports of the campaign file are written unless the format or output is given.
+	reports := campaign.Reports
+	if campaign.given["format"] || campaign.given["output"] || len(reports) == 0 {
+		reports = []tester.CampaignReport{{Format: campaign.format, Path: campaign.output}}
+	}
+	root := campaign.root
+	if root == "" {
+		root = moduleRoot(ctx, campaign.Package)
+	}
+	for _, destination := range reports {
+		if err := writeFindings(destination, root, findings, stdout); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// The directory of the module of the package or empty if it is unknown.
+func moduleRoot(ctx context.Context, pkg string) string {
+	output, err := exec.CommandContext(ctx, "go", "list", "-f", "{{.Module.Dir}}", pkg).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+func writeFindings(destination tester.CampaignReport, root string, findings []report.Finding, stdout io.Writer) error {
+	writer := stdout
+	if destination.Path != "" {
+		file, err := os.Create(destination.Path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		writer = file
+	}
+
+	switch destination.Format {
+	case "jsonl":
+		return report.WriteJSONLines(writer, findings)
+	case "csv":
+		return report.WriteCSV(writer, findings)
+	case "sarif":
+		return report.WriteSARIF(writer, root, findings)
+	case "terminal":
+		return report.NewHeatmap(findings).WriteTerminal(writer, destination.Path == "" && isTerminal(stdout))
+	case "html":
+		return report.NewHeatmap(findings).WriteHTML(writer)
+	default:
+		return fmt.Errorf("%w format %q", errUnknown, destination.Format)
+	}
+}
+
+// Parses the names of outcomes. If there are none then the defaults are used.
+func outcomes(names []string, defaults ...tester.Outcome) ([]tester.Outcome, error) {
+	if len(names) == 0 {
+		return defaults, nil
+	}
+
+	outcomes := make([]tester.Outcome, len(names))
+	for i, name := range names {
+		if err := outcomes[i].UnmarshalText([]byte(strings.TrimSpace(name))); err != nil {
+			return nil, err
+		}
+	}
+	return outcomes, nil
+}
+
+func isTerminal(writer io.Writer) bool {
+	file, ok := writer.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,112 @@
+// Gorrupt runs fault injection campaigns against a function of a Go package without a hand-written test.
+//
+// Usage:
+//
+//	gorrupt <command> [flags]
+//
+// The commands are:
+//
+//	targets  lists the targets of the fault models in the functions
+//	plan     lists the attack plans of the targets
+//	run      executes every attack plan and reports the executions violating the property
+//	replay   executes a single attack plan
+//	report   writes the findings of the store of a campaign
+//
+// The input type of the package must have a method Call which returns the output like in the examples, e.g.,
+//
+//	gorrupt run -package github.com/hyperproperties/gorrupt/examples/fissc/VerifyPIN_0/pkg \
+//		-type VerifyPINInput -function VerifyPIN -function PINCompare -model bfr -model is \
+//		-input '{"UserPIN":[9,9,9,9]}' -qemu /path/to/qemu-arm
+//
+// A campaign can also be described by a YAML or JSON file (see tester.Campaign) with -config whose fields
+// are overridden by the flags given on the command line, e.g.,
+//
+//	gorrupt run -config examples/fissc/VerifyPIN_0/campaign.yaml -qemu /path/to/qemu-arm
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+)
+
+type command struct {
+	name        string
+	description string
+	// Defines the flags of the command in addition to those of every campaign (if any).
+	define func(campaign *campaign)
+	run    func(ctx context.Context, campaign *campaign, arguments []string, stdout io.Writer) error
+}
+
+var commands = []command{
+	{"targets", "lists the targets of the fault models in the functions", nil, listTargets},
+	{"plan", "lists the attack plans of the targets", nil, listPlans},
+	{"run", "executes every attack plan and reports the executions violating the property", defineRun, runCampaign},
+	{"replay", "executes a single attack plan", defineReplay, replayPlan},
+	{"report", "writes the findings of the store of a campaign", defineReport, writeReport},
+}
+
+var (
+	// Returned by run if some execution violates the property.
+	errViolated = errors.New("the property is violated")
+	// Returned for unknown commands and formats.
+	errUnknown = errors.New("unknown")
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	err := execute(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	switch {
+	case errors.Is(err, flag.ErrHelp):
+		os.Exit(2)
+	case errors.Is(err, errViolated):
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	case err != nil:
+		fmt.Fprintln(os.Stderr, "gorrupt:", err)
+		os.Exit(2)
+	}
+}
+
+func execute(ctx context.Context, arguments []string, stdout, stderr io.Writer) error {
+	if len(arguments) == 0 {
+		usage(stderr)
+		return flag.ErrHelp
+	}
+
+	for _, command := range commands {
+		if command.name != arguments[0] {
+			continue
+		}
+
+		campaign := newCampaign(command.name, stderr)
+		if command.define != nil {
+			command.define(campaign)
+		}
+		if err := campaign.parse(arguments[1:]); err != nil {
+			return err
+		}
+
+		return command.run(ctx, campaign, campaign.flags.Args(), stdout)
+	}
+
+	usage(stderr)
+	return fmt.Errorf("%w command %q", errUnknown, arguments[0])
+}
+
+func usage(writer io.Writer) {
+	fmt.Fprintln(writer, "usage: gorrupt <command> [flags]")
+	fmt.Fprintln(writer)
+	fmt.Fprintln(writer, "The commands are:")
+	for _, command := range commands {
+		fmt.Fprintf(writer, "  %-8s %s\n", command.name, command.description)
+	}
+	fmt.Fprintln(writer)
+	fmt.Fprintln(writer, `Use "gorrupt <command> -h" for the flags of a command.`)
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperproperties/gorrupt/pkg/fi/tester"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommands(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	qemu, err := filepath.Abs("../../pkg/fi/tester/testdata/qemu.sh")
+	assert.NoError(t, err)
+
+	// The campaign file gives the fields which are common to every command.
+	directory := t.TempDir()
+	config := filepath.Join(directory, "campaign.yaml")
+	assert.NoError(t, os.WriteFile(config, []byte(fmt.Sprintf(`
+package: github.com/hyperproperties/gorrupt/examples/fissc/VerifyPIN_0/pkg
+input: VerifyPINInput
+inputs:
+  - UserPIN: [9, 9, 9, 9]
+qemu: %s
+functions: [VerifyPIN]
+searchers: [is]
+directory: %s
+detector: Countermeasure
+reports:
+  - format: csv
+    path: %s
+`, qemu, directory, filepath.Join(directory, "findings.csv"))), 0644))
+
+	tests := []struct {
+		description string
+		arguments   []string
+		empty       bool
+		contains    string
+		excludes    string
+		err         error
+	}{
+		{
+			description: "targets",
+			arguments:   []string{"targets", "-config", config},
+			contains:    "is 0x",
+		},
+		{
+			description: "plans",
+			arguments:   []string{"plan", "-config", config},
+			contains:    "[is ",
+		},
+		{
+			description: "holding property",
+			arguments:   []string{"run", "-config", config, "-parallel", "4"},
+			empty:       true,
+		},
+		{
+			description: "violated property",
+			arguments:   []string{"run", "-config", config, "-allow", "crash"},
+			contains:    `golden [is `,
+			err:         errViolated,
+		},
+		{
+			description: "replay",
+			arguments:   []string{"replay", "-config", config, "-plan", "[is 4100 0]"},
+			contains:    "outcome: corruption",
+		},
+		{
+			description: "report",
+			arguments:   []string{"report", "-config", config, "-format", "csv", "-outcome", "golden"},
+			contains:    "verify_pin.go",
+		},
+		{
+			description: "report all but golden by default",
+			arguments:   []string{"report", "-config", config, "-format", "csv"},
+			contains:    "plan,outcome,attack",
+			excludes:    ",golden,",
+		},
+		{
+			description: "sarif relative to the module",
+			arguments:   []string{"report", "-config", config, "-format", "sarif", "-outcome", "golden"},
+			contains:    `"uri": "examples/fissc/VerifyPIN_0/pkg/verify_pin.go"`,
+		},
+		{
+			description: "flags take precedence over the configuration",
+			arguments:   []string{"targets", "-config", config, "-function", "PINCompare"},
+			contains:    "is 0x",
+		},
+		{
+			description: "unknown model",
+			arguments:   []string{"targets", "-config", config, "-model", "glitch"},
+			err:         tester.ErrUnknownSearcher,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			err := execute(ctx, tt.arguments, &stdout, &stderr)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err, stderr.String())
+			}
+			if tt.empty {
+				assert.Empty(t, stdout.String())
+			}
+			assert.Contains(t, stdout.String(), tt.contains)
+			if tt.excludes != "" {
+				assert.NotContains(t, stdout.String(), tt.excludes)
+			}
+		})
+	}
+
+	// The reports of the campaign file are written unless the format or output is given.
+	var stdout, stderr bytes.Buffer
+	assert.NoError(t, execute(ctx, []string{"report", "-config", config, "-outcome", "golden"}, &stdout, &stderr))
+	assert.Empty(t, stdout.String())
+	findings, err := os.ReadFile(filepath.Join(directory, "findings.csv"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(findings), "verify_pin.go")
+}
+
+func TestUnknownCommand(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	assert.ErrorIs(t, execute(context.Background(), []string{"attack"}, &stdout, &stderr), errUnknown)
+	assert.Contains(t, stderr.String(), "usage: gorrupt <command> [flags]")
+}
@@ -8,8 +8,6 @@ import (
 	"time"
 
 	"github.com/hyperproperties/gorrupt/examples/fissc/VerifyPIN_4/pkg"
-	"github.com/hyperproperties/gorrupt/pkg/fi"
-	"github.com/hyperproperties/gorrupt/pkg/fi/arm"
 	"github.com/hyperproperties/gorrupt/pkg/fi/tester"
 	"github.com/hyperproperties/gorrupt/pkg/iterx"
 	"github.com/hyperproperties/gorrupt/pkg/quick"
@@ -19,71 +17,69 @@ func Test(t *testing.T) {
 	context, cancel := context.WithTimeout(context.Background(), time.Hour)
 	defer cancel()
 
-	runner := tester.NewRunner[pkg.VerifyPINInput, pkg.VerifyPINOutput](
-		"/home/andreas/git/qemu-fi/build-arm/qemu-arm",
-		"github.com/hyperproperties/gorrupt/examples/fissc/VerifyPIN_4/pkg",
-		"pkg", "VerifyPIN",
-		"GOARCH=arm", "GOOS=linux",
-	)
+	// The package, functions, searchers, and parallelism of the campaign are described by campaign.yaml.
+	campaign, err := tester.LoadCampaign("campaign.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	runner, err := tester.CampaignRunner[pkg.VerifyPINInput, pkg.VerifyPINOutput](campaign)
+	if err != nil {
+		t.Fatal(err)
+	}
 
-	pack := "github.com/hyperproperties/gorrupt/examples/fissc/VerifyPIN_4/pkg"
-	verifyPIN := tester.FunctionInPackage(pack, "VerifyPIN")
+	// The seed of the input and directories is logged to reproduce the campaign.
+	generator := tester.GeneratorTest(t)
+	input := quick.Generate[pkg.VerifyPINInput](generator)
 
-	e0Dir := path.Join(".", quick.String(32, quick.USAlphabet...))
+	// Replays a single attack plan if requested, e.g., GORRUPT_REPLAY="[...]" GORRUPT_SEED=1234.
+	if tester.ReplayTest(t, context, runner, input) {
+		return
+	}
+
+	e0Dir := path.Join(".", generator.String(32, quick.USAlphabet...))
 	os.MkdirAll(e0Dir, os.ModePerm)
 	defer os.RemoveAll(e0Dir + "/")
 
-	e1Dir := path.Join(".", quick.String(32, quick.USAlphabet...))
+	e1Dir := path.Join(".", generator.String(32, quick.USAlphabet...))
 	os.MkdirAll(e1Dir, os.ModePerm)
 	defer os.RemoveAll(e1Dir + "/")
 
 	// forall e0.
-	ok, err := runner.Forall(
+	result, err := runner.Forall(
 		context, tester.NewQuantifierConfiguration(
 			tester.WithDirectory(e0Dir),
-			tester.WithTimeout(time.Minute),
+			tester.WithTimeout(campaign.Timeout),
 		),
-		iterx.Once2(quick.New[pkg.VerifyPINInput]()),
-		func(
-			e0Input pkg.VerifyPINInput, e0Output pkg.VerifyPINOutput, plan fi.AttackPlan,
-		) (bool, error) {
+		iterx.Once2(input),
+		func(e0 tester.Execution[pkg.VerifyPINInput, pkg.VerifyPINOutput]) (bool, error) {
 			
-			// forall e1 under BFR.
-			return runner.ForallParallel(
-				context, tester.NewQuantifierConfiguration(
-					tester.WithTargetOptions(
-						tester.LinearSearchTargets(
-							arm.NewBFRLinearSearch(), verifyPIN,
-						),
-						tester.LinearSearchTargets(
-							arm.NewICLinearSearch(), verifyPIN,
-						),
-						tester.LinearSearchTargets(
-							arm.NewISLinearSearch(), verifyPIN,
-						),
-					),
-					tester.WithDirectory(e1Dir),
-					tester.WithTimeout(time.Minute),
-				).Parallel(
-					tester.WithPool(512),
-					tester.WithBatch(4096),
-				),
-				iterx.Once2(e0Input),
-				func(
-					e1Input pkg.VerifyPINInput, e1Output pkg.VerifyPINOutput, plan fi.AttackPlan,
-				) (bool, error) {
-					if !e1Output.Countermeasure {
-						t.Error("Undetected: " + plan.String())
+			// forall e1 under the searchers of the campaign.
+			configuration, err := campaign.Parallel(tester.WithDirectory(e1Dir))
+			if err != nil {
+				return false, err
+			}
+			result, err := runner.ForallParallel(
+				context, configuration,
+				iterx.Once2(e0.Input),
+				func(e1 tester.Execution[pkg.VerifyPINInput, pkg.VerifyPINOutput]) (bool, error) {
+					// Crashes, timeouts, and emulator errors have no output to check.
+					if !e1.Outcome.HasOutput() {
+						return true, nil
+					}
+
+					if !e1.Output.Countermeasure {
+						t.Error("Undetected: " + e1.Plan.String())
 					}
-					if e0Output.Ret0 != e1Output.Ret0 {
-						t.Error("OD Violation: " + plan.String())
+					if e0.Output.Ret0 != e1.Output.Ret0 {
+						t.Error("OD Violation: " + e1.Plan.String())
 					}
-					if !e1Output.Countermeasure && e1Output.Ret0 == pkg.TrueHB {
-						t.Error("Undetected access: " + plan.String())
+					if !e1.Output.Countermeasure && e1.Output.Ret0 == pkg.TrueHB {
+						t.Error("Undetected access: " + e1.Plan.String())
 					}
 					return true, nil
 				},
 			)
+			return result.Holds, err
 		},
 	)
 
@@ -91,7 +87,7 @@ func Test(t *testing.T) {
 		t.Error(err)
 	}
 
-	if !ok {
-		t.Error()
+	if !result.Holds {
+		t.Error(result)
 	}
 }
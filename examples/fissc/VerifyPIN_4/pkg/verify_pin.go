@@ -109,7 +109,8 @@ func VerifyPIN() HardendBool {
 
 
 type VerifyPINInput struct {
-	UserPIN [PINSize]byte
+	// The PIN entered by the user consists of digits.
+	UserPIN [PINSize]byte `quick:"min=0,max=9"`
 }
 
 func (input VerifyPINInput) Call() VerifyPINOutput {
@@ -126,4 +127,9 @@ type VerifyPINOutput struct {
 	Ret0 HardendBool
 	Countermeasure bool
 	PTC int8
+}
+
+// Reports whether a countermeasure detected a fault.
+func (output VerifyPINOutput) Detected() bool {
+	return output.Countermeasure
 }
\ No newline at end of file
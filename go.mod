@@ -2,11 +2,14 @@ module github.com/hyperproperties/gorrupt
 
 go 1.23.1
 
-require github.com/stretchr/testify v1.10.0
+require (
+	github.com/alitto/pond/v2 v2.1.6
+	github.com/stretchr/testify v1.10.0
+	golang.org/x/arch v0.20.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
-	github.com/alitto/pond/v2 v2.1.6 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,91 @@
+package arm
+
+import (
+	"slices"
+
+	"github.com/hyperproperties/gorrupt/pkg/fi"
+	"github.com/hyperproperties/gorrupt/pkg/obj"
+)
+
+var _ fi.LinearSearcher[fi.BFMTarget] = (*BFMLinearSearch)(nil)
+
+type BFMLinearSearch struct{}
+
+func NewBFMLinearSearch() BFMLinearSearch {
+	return BFMLinearSearch{}
+}
+
+// Searches for BFM targets in the instructions.
+//
+// Go loads the address of a global from a literal pool, e.g., "MOVW 0xd4(R15), R11",
+// before accessing it through the register, e.g., "MOVBS (R11), R0". The searcher tracks
+// registers holding such addresses and yields a target for every load and store through them.
+// Loads are attacked before and stores after the instruction is executed.
+func (searcher BFMLinearSearch) Instructions(instructions []obj.Instruction) (targets []fi.BFMTarget) {
+	literals := make(map[uint64]uint64, len(instructions))
+	for _, instruction := range instructions {
+		literals[instruction.Offset()] = instruction.Opcode()
+	}
+
+	// The registers currently holding a known address.
+	addresses := make(map[Register]uint64)
+
+	for i := range instructions {
+		instruction, err := Decode(instructions[i])
+		if err != nil {
+			continue
+		}
+
+		offset := fi.PC(instruction.Offset())
+
+		if instruction.IsBranch() || instruction.IsCall() {
+			clear(addresses)
+			continue
+		}
+
+		memory, ok := instruction.Memory()
+		if ok && len(memory.Index) == 0 {
+			// Loading an address from the literal pool (PC reads as the current instruction + 8).
+			if instruction.IsLoad() && memory.Base == R15 {
+				if literal, exists := literals[instruction.Offset()+8+uint64(memory.Offset)]; exists {
+					addresses[instruction.Destinations()[0]] = literal
+					continue
+				}
+			}
+
+			if address, exists := addresses[memory.Base]; exists {
+				if memory.Addressing != PostIndexAddressing {
+					address += uint64(memory.Offset)
+				}
+
+				if instruction.IsLoad() {
+					target := fi.NewBFMTarget(fi.NewTransition(offset-4, offset), address, memory.Width)
+					targets = append(targets, target)
+				} else if instruction.IsStore() {
+					target := fi.NewBFMTarget(fi.NewTransition(offset, offset+4), address, memory.Width)
+					targets = append(targets, target)
+				}
+			}
+		}
+
+		// Forget the registers which have been overwritten.
+		for _, register := range instruction.Destinations() {
+			delete(addresses, register)
+		}
+	}
+
+	// Removes duplicate targets.
+	targets = unique(targets)
+
+	return
+}
+
+// Removes the duplicates from the targets while keeping the order of their first occurrences.
+func unique[T comparable](targets []T) []T {
+	seen := make(map[T]struct{}, len(targets))
+	return slices.DeleteFunc(targets, func(target T) bool {
+		_, exists := seen[target]
+		seen[target] = struct{}{}
+		return exists
+	})
+}
@@ -0,0 +1,85 @@
+package arm
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/hyperproperties/gorrupt/pkg/fi"
+	"github.com/hyperproperties/gorrupt/pkg/obj"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBFMSearcher(t *testing.T) {
+	tests := []struct {
+		description  string
+		instructions []obj.Instruction
+		bfms         []fi.BFMTarget
+	}{
+		{
+			description:  "no instructions",
+			instructions: []obj.Instruction{},
+			bfms:         nil,
+		},
+		{
+			description: "load from global",
+			instructions: []obj.Instruction{
+				obj.NewInstruction("verify_pin.go:71", 0x146eb4, 0xe59fb0d4, "MOVW 0xd4(R15), R11"),
+				obj.NewInstruction("verify_pin.go:71", 0x146eb8, 0xe1db00d0, "MOVBS (R11), R0"),
+				obj.NewInstruction("verify_pin.go:70", 0x146f90, 0x002703d8, "?"),
+			},
+			bfms: []fi.BFMTarget{
+				fi.NewBFMTarget(fi.NewTransition(0x146eb4, 0x146eb8), 0x2703d8, 1),
+			},
+		},
+		{
+			description: "store to global with offset",
+			instructions: []obj.Instruction{
+				obj.NewInstruction("verify_pin.go:73", 0x146f40, 0xe59fb048, "MOVW 0x48(R15), R11"),
+				obj.NewInstruction("verify_pin.go:73", 0x146f44, 0xe58b0004, "MOVW R0, 0x4(R11)"),
+				obj.NewInstruction("verify_pin.go:70", 0x146f90, 0x002703d8, "?"),
+			},
+			bfms: []fi.BFMTarget{
+				fi.NewBFMTarget(fi.NewTransition(0x146f44, 0x146f48), 0x2703dc, 4),
+			},
+		},
+		{
+			description: "address overwritten",
+			instructions: []obj.Instruction{
+				obj.NewInstruction("verify_pin.go:71", 0x146eb4, 0xe59fb0d4, "MOVW 0xd4(R15), R11"),
+				obj.NewInstruction("verify_pin.go:71", 0x146eb8, 0xe3a0b003, "MOVW $3, R11"),
+				obj.NewInstruction("verify_pin.go:71", 0x146ebc, 0xe1db00d0, "MOVBS (R11), R0"),
+				obj.NewInstruction("verify_pin.go:70", 0x146f94, 0x002703d8, "?"),
+			},
+			bfms: nil,
+		},
+		{
+			description: "function given twice",
+			instructions: slices.Repeat([]obj.Instruction{
+				obj.NewInstruction("verify_pin.go:71", 0x146eb4, 0xe59fb0d4, "MOVW 0xd4(R15), R11"),
+				obj.NewInstruction("verify_pin.go:71", 0x146eb8, 0xe1db00d0, "MOVBS (R11), R0"),
+				obj.NewInstruction("verify_pin.go:73", 0x146ebc, 0xe59fb0cc, "MOVW 0xcc(R15), R11"),
+				obj.NewInstruction("verify_pin.go:73", 0x146ec0, 0xe58b0004, "MOVW R0, 0x4(R11)"),
+				obj.NewInstruction("verify_pin.go:70", 0x146f90, 0x002703d8, "?"),
+			}, 2),
+			bfms: []fi.BFMTarget{
+				fi.NewBFMTarget(fi.NewTransition(0x146eb4, 0x146eb8), 0x2703d8, 1),
+				fi.NewBFMTarget(fi.NewTransition(0x146ec0, 0x146ec4), 0x2703dc, 4),
+			},
+		},
+		{
+			description: "stack access",
+			instructions: []obj.Instruction{
+				obj.NewInstruction("verify_pin.go:72", 0x146ee4, 0xe5cd0004, "MOVB R0, 0x4(R13)"),
+			},
+			bfms: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			searcher := NewBFMLinearSearch()
+			bfms := searcher.Instructions(tt.instructions)
+			assert.ElementsMatch(t, tt.bfms, bfms)
+		})
+	}
+}
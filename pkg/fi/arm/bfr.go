@@ -1,7 +1,6 @@
 package arm
 
 import (
-	"fmt"
 	"slices"
 
 	"github.com/hyperproperties/gorrupt/pkg/fi"
@@ -9,6 +8,7 @@ import (
 )
 
 var _ fi.LinearSearcher[fi.BFRTarget] = (*BFRLinearSearch)(nil)
+var _ fi.LinearSearcher[fi.BFRTarget] = (*BFRFlowSearch)(nil)
 
 type BFRLinearSearch struct{}
 
@@ -17,20 +17,21 @@ func NewBFRLinearSearch() BFRLinearSearch {
 }
 
 // Searches for BFR targets in the instructions.
+// The registers read by an instruction, including the base and index registers of its memory operand,
+// are attacked before it is executed and the registers it writes are attacked after it has been executed.
 func (searcher BFRLinearSearch) Instructions(instructions []obj.Instruction) (targets []fi.BFRTarget) {
 	for i := range instructions {
-		instruction, err := NewInstruction(instructions[i])
+		instruction, err := Decode(instructions[i])
 		if err != nil {
-			fmt.Println(err)
+			continue
 		}
 
-		// Get all registers in instruction in order. The first one is usually the destination.
-		registers := instruction.Registers()
-		if len(registers) > 0 {
-			targets = append(targets, searcher.targetBefore(registers[0], fi.PC(instruction.Offset()))...)
-			for _, register := range slices.Compact(registers) {
-				targets = append(targets, searcher.targetAfter(register, fi.PC(instruction.Offset()))...)
-			}
+		offset := fi.PC(instruction.Offset())
+		for _, register := range instruction.Sources() {
+			targets = append(targets, searcher.targetBefore(register, offset)...)
+		}
+		for _, register := range instruction.Destinations() {
+			targets = append(targets, searcher.targetAfter(register, offset)...)
 		}
 	}
 
@@ -48,12 +49,78 @@ func (searcher BFRLinearSearch) targetAfter(register Register, offset fi.PC) (ta
 	return searcher.target(register, offset, offset+4)
 }
 
-// Creates the BFR target if the register has an index and is therefore supported by qemu-fi.
 func (searcher BFRLinearSearch) target(register Register, source, destination fi.PC) (targets []fi.BFRTarget) {
+	return bfrTarget(register, fi.NewTransition(source, destination))
+}
+
+// Creates the BFR target if the register has an index and is therefore supported by qemu-fi.
+// The PC is excluded since faults on the control-flow are modelled by IS and IC.
+func bfrTarget(register Register, transition fi.Transition) (targets []fi.BFRTarget) {
+	if register == R15 {
+		return
+	}
+
 	if index, err := register.Index(); err == nil {
-		transition := fi.NewTransition(source, destination)
 		target := fi.NewBFRTarget(transition, index)
 		targets = append(targets, target)
 	}
 	return
 }
+
+type BFRFlowSearch struct{}
+
+func NewBFRFlowSearch() BFRFlowSearch {
+	return BFRFlowSearch{}
+}
+
+// Searches for BFR targets like BFRLinearSearch but on the transitions of the CFG of the instructions
+// rather than on their neighbours. E.g., the registers read at the target of a conditional branch
+// are also attacked on the taken edge from the branch. The instructions must be those of a single function.
+// Transitions from callers and to the caller are unknown and therefore "0" (any).
+func (searcher BFRFlowSearch) Instructions(instructions []obj.Instruction) []fi.BFRTarget {
+	cfg := fi.NewCFG(instructions, NewFlowClassifier())
+	return flowTargets(instructions, cfg, func(Register, fi.Transition) bool {
+		return true
+	})
+}
+
+// The BFR targets of the registers read on the transitions into each instruction and of
+// the registers written on the transitions from it for which the filter holds.
+func flowTargets(instructions []obj.Instruction, cfg fi.CFG, filter func(register Register, transition fi.Transition) bool) (targets []fi.BFRTarget) {
+	for i := range instructions {
+		instruction, err := Decode(instructions[i])
+		if err != nil {
+			continue
+		}
+
+		offset := fi.PC(instruction.Offset())
+		into := cfg.Into(offset)
+		if len(into) == 0 || i == 0 {
+			into = []fi.Transition{fi.NewTransition(0, offset)}
+		}
+		from := cfg.From(offset)
+		if len(from) == 0 {
+			from = []fi.Transition{fi.NewTransition(offset, 0)}
+		}
+
+		for _, register := range instruction.Sources() {
+			for _, transition := range into {
+				if filter(register, transition) {
+					targets = append(targets, bfrTarget(register, transition)...)
+				}
+			}
+		}
+		for _, register := range instruction.Destinations() {
+			for _, transition := range from {
+				if filter(register, transition) {
+					targets = append(targets, bfrTarget(register, transition)...)
+				}
+			}
+		}
+	}
+
+	// Removes duplicate targets.
+	targets = unique(targets)
+
+	return
+}
@@ -38,13 +38,37 @@ func TestBFRSearcher(t *testing.T) {
 			},
 			bfrs: []fi.BFRTarget{
 				fi.NewBFRTarget(fi.NewTransition(0x11078-4, 0x11078), 0),
+				fi.NewBFRTarget(fi.NewTransition(0x11078-4, 0x11078), 13),
 			},
-			length: 1,
+			length: 2,
+		},
+		{
+			description: "load with base and index registers",
+			instructions: []obj.Instruction{
+				obj.NewInstruction("verify_pin.go:62", 0x146e5c, 0xe7d23000, "MOVBU (R2)(R0), R3"),
+			},
+			bfrs: []fi.BFRTarget{
+				fi.NewBFRTarget(fi.NewTransition(0x146e5c-4, 0x146e5c), 2),
+				fi.NewBFRTarget(fi.NewTransition(0x146e5c-4, 0x146e5c), 0),
+				fi.NewBFRTarget(fi.NewTransition(0x146e5c, 0x146e5c+4), 3),
+			},
+			length: 3,
+		},
+		{
+			description: "store with base register",
+			instructions: []obj.Instruction{
+				obj.NewInstruction("abi.go:58", 0x11078, 0xe58d0008, "MOVW R0, 0x8(R13)"),
+			},
+			bfrs: []fi.BFRTarget{
+				fi.NewBFRTarget(fi.NewTransition(0x11078-4, 0x11078), 0),
+				fi.NewBFRTarget(fi.NewTransition(0x11078-4, 0x11078), 13),
+			},
+			length: 2,
 		},
 		{
 			description: "no instructions",
 			instructions: []obj.Instruction{
-				obj.NewInstruction("abi.go:58", 0x11078, 0xe58d0008, "MOVW R3, R4"),
+				obj.NewInstruction("abi.go:58", 0x11078, 0xe1a04003, "MOVW R3, R4"),
 			},
 			bfrs: []fi.BFRTarget{
 				fi.NewBFRTarget(fi.NewTransition(0x11078-4, 0x11078), 3),
@@ -0,0 +1,460 @@
+package arm
+
+import (
+	"encoding/binary"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hyperproperties/gorrupt/pkg/obj"
+	"golang.org/x/arch/arm/armasm"
+)
+
+const (
+	// Data-processing instructions writing a register, e.g., ADD, LSL, MUL, and UXTB.
+	OP_DATA = Operation(iota + OP_MOV + 1)
+	// Data-processing instructions only setting the flags: CMP, CMN, TST, and TEQ.
+	OP_COMPARE
+	// Loads of one or more registers from memory, e.g., LDR, LDRB, LDM, and POP.
+	OP_LOAD
+	// Stores of one or more registers to memory, e.g., STR, STRB, STM, and PUSH.
+	OP_STORE
+	// Branches without linking: B and BX.
+	OP_BRANCH
+	// Branches with linking: BL and BLX.
+	OP_CALL
+	// Floating-point (VFP) instructions.
+	OP_FLOAT
+	// Instructions which neither compute nor move data, e.g., SVC, NOP, and BKPT.
+	OP_SYSTEM
+)
+
+// The condition under which an instruction is executed.
+type Condition byte
+
+const (
+	EQ = Condition(iota) // Equal
+	NE                   // Not equal
+	CS                   // Carry set (unsigned higher or same)
+	CC                   // Carry clear (unsigned lower)
+	MI                   // Negative
+	PL                   // Positive or zero
+	VS                   // Overflow
+	VC                   // No overflow
+	HI                   // Unsigned higher
+	LS                   // Unsigned lower or same
+	GE                   // Signed greater than or equal
+	LT                   // Signed less than
+	GT                   // Signed greater than
+	LE                   // Signed less than or equal
+	AL                   // Always
+)
+
+var conditions = [...]string{"EQ", "NE", "CS", "CC", "MI", "PL", "VS", "VC", "HI", "LS", "GE", "LT", "GT", "LE", "AL"}
+
+func (condition Condition) String() string {
+	if int(condition) < len(conditions) {
+		return conditions[condition]
+	}
+	return fmt.Sprintf("Condition(%d)", condition)
+}
+
+// How the address of a memory operand is computed from its base register.
+type Addressing byte
+
+const (
+	// The address is base+offset and the base is unchanged.
+	OffsetAddressing = Addressing(iota)
+	// The address is base+offset and the base is updated to the address.
+	PreIndexAddressing
+	// The address is the base and the base is updated to base+offset.
+	PostIndexAddressing
+)
+
+// The memory operand of a load or store.
+type Memory struct {
+	Base Register
+	// The register added to (or subtracted from) the base. Empty if the offset is immediate.
+	Index      Register
+	Offset     int64
+	Addressing Addressing
+	// The number of bytes accessed.
+	Width byte
+}
+
+// Checks if the base register is updated by the access.
+func (memory Memory) Writeback() bool {
+	return memory.Addressing != OffsetAddressing
+}
+
+// A register operand shifted by a constant or by another register.
+type Shift struct {
+	Register Register
+	// One of "LSL", "LSR", "ASR", "ROR", or "RRX".
+	Operation string
+	Amount    uint8
+	// The register holding the shift amount. Empty if the amount is constant.
+	By Register
+}
+
+// An instruction decoded from its opcode.
+type Decoded struct {
+	obj.Instruction
+	operation    Operation
+	mnemonic     string
+	condition    Condition
+	flags        bool
+	destinations []Register
+	sources      []Register
+	// The sources read only to compute the address of a memory operand.
+	addresses []Register
+	immediate *int64
+	memory    *Memory
+	shift     *Shift
+	target    *uint64
+}
+
+// Decodes the instruction from its (ARM mode) opcode.
+func Decode(instruction obj.Instruction) (Decoded, error) {
+	var raw [4]byte
+	binary.LittleEndian.PutUint32(raw[:], uint32(instruction.Opcode()))
+
+	inst, err := armasm.Decode(raw[:], armasm.ModeARM)
+	if err != nil || inst.Op == 0 {
+		var zero Decoded
+		return zero, ErrUnknownInstruction
+	}
+
+	// The name of the operation is the mnemonic followed by an optional ".S" and condition. E.g., "ADD.S.EQ".
+	parts := strings.Split(inst.Op.String(), ".")
+	decoded := Decoded{
+		Instruction: instruction,
+		mnemonic:    parts[0],
+		condition:   AL,
+	}
+	for _, part := range parts[1:] {
+		if part == "S" {
+			decoded.flags = true
+		} else if idx := slices.Index(conditions[:], part); idx >= 0 {
+			decoded.condition = Condition(idx)
+		}
+	}
+	decoded.operation = operationOf(decoded.mnemonic)
+
+	written := writtenArguments(decoded.mnemonic)
+	for idx, arg := range inst.Args {
+		if arg == nil {
+			break
+		}
+
+		switch arg := arg.(type) {
+		case armasm.Reg:
+			if register, ok := registerOf(arg); ok {
+				if idx < written {
+					decoded.write(register)
+				} else {
+					decoded.read(register)
+				}
+			}
+		case armasm.RegShift:
+			register, _ := registerOf(arg.Reg)
+			decoded.read(register)
+			decoded.shift = &Shift{
+				Register:  register,
+				Operation: arg.Shift.String(),
+				Amount:    arg.Count,
+			}
+		case armasm.RegShiftReg:
+			register, _ := registerOf(arg.Reg)
+			by, _ := registerOf(arg.RegCount)
+			decoded.read(register)
+			decoded.read(by)
+			decoded.shift = &Shift{
+				Register:  register,
+				Operation: arg.Shift.String(),
+				By:        by,
+			}
+		case armasm.RegList:
+			for i := 0; i < 16; i++ {
+				if arg&(1<<i) == 0 {
+					continue
+				}
+				register, _ := registerOf(armasm.Reg(i))
+				if decoded.operation == OP_LOAD {
+					decoded.write(register)
+				} else {
+					decoded.read(register)
+				}
+			}
+		case armasm.Imm:
+			immediate := int64(arg)
+			decoded.immediate = &immediate
+		case armasm.ImmAlt:
+			immediate := int64(arg.Imm())
+			decoded.immediate = &immediate
+		case armasm.Mem:
+			decoded.memory = decoded.memoryOf(arg)
+		case armasm.PCRel:
+			// Reading the PC in ARM mode yields the address of the current instruction + 8.
+			target := uint64(int64(instruction.Offset()) + 8 + int64(arg))
+			decoded.target = &target
+		}
+	}
+
+	switch decoded.mnemonic {
+	case "BL", "BLX":
+		decoded.write(R14)
+	case "PUSH", "POP":
+		decoded.readAddress(R13)
+		decoded.write(R13)
+	case "MOVT", "BFI", "BFC", "UMLAL", "SMLAL", "UMAAL", "SMLALD", "SMLSLD", "SMLALBB", "SMLALBT", "SMLALTB", "SMLALTT":
+		// Only part of the destination is written so the remaining part is read.
+		for _, register := range decoded.destinations {
+			decoded.read(register)
+		}
+	}
+
+	return decoded, nil
+}
+
+func (decoded *Decoded) read(register Register) {
+	if !slices.Contains(decoded.sources, register) {
+		decoded.sources = append(decoded.sources, register)
+	}
+	decoded.addresses = slices.DeleteFunc(decoded.addresses, func(address Register) bool {
+		return address == register
+	})
+}
+
+func (decoded *Decoded) readAddress(register Register) {
+	if !slices.Contains(decoded.sources, register) {
+		decoded.sources = append(decoded.sources, register)
+		decoded.addresses = append(decoded.addresses, register)
+	}
+}
+
+func (decoded *Decoded) write(register Register) {
+	if !slices.Contains(decoded.destinations, register) {
+		decoded.destinations = append(decoded.destinations, register)
+	}
+}
+
+func (decoded *Decoded) memoryOf(mem armasm.Mem) *Memory {
+	base, _ := registerOf(mem.Base)
+	decoded.readAddress(base)
+
+	memory := Memory{
+		Base:   base,
+		Offset: int64(mem.Offset),
+		Width:  widthOf(decoded.mnemonic),
+	}
+
+	if mem.Sign != 0 {
+		memory.Index, _ = registerOf(mem.Index)
+		decoded.readAddress(memory.Index)
+	}
+
+	switch mem.Mode {
+	case armasm.AddrPreIndex:
+		memory.Addressing = PreIndexAddressing
+	case armasm.AddrPostIndex:
+		memory.Addressing = PostIndexAddressing
+	case armasm.AddrLDM_WB:
+		memory.Addressing = PostIndexAddressing
+	}
+
+	if memory.Writeback() {
+		decoded.write(base)
+	}
+
+	return &memory
+}
+
+// The operation of the instruction. E.g., OP_LOAD.
+func (decoded Decoded) Operation() Operation {
+	return decoded.operation
+}
+
+// The mnemonic without condition or flags. E.g., "LDRB".
+func (decoded Decoded) Mnemonic() string {
+	return decoded.mnemonic
+}
+
+func (decoded Decoded) Condition() Condition {
+	return decoded.condition
+}
+
+// Checks if the instruction is only executed under some condition.
+func (decoded Decoded) IsConditional() bool {
+	return decoded.condition != AL
+}
+
+// Checks if the instruction updates the condition flags.
+func (decoded Decoded) SetsFlags() bool {
+	return decoded.flags || decoded.operation == OP_COMPARE
+}
+
+// The general-purpose registers written by the instruction.
+func (decoded Decoded) Destinations() []Register {
+	return decoded.destinations
+}
+
+// The general-purpose registers read by the instruction.
+func (decoded Decoded) Sources() []Register {
+	return decoded.sources
+}
+
+func (decoded Decoded) Immediate() (int64, bool) {
+	if decoded.immediate == nil {
+		return 0, false
+	}
+	return *decoded.immediate, true
+}
+
+func (decoded Decoded) Memory() (Memory, bool) {
+	if decoded.memory == nil {
+		return Memory{}, false
+	}
+	return *decoded.memory, true
+}
+
+func (decoded Decoded) Shift() (Shift, bool) {
+	if decoded.shift == nil {
+		return Shift{}, false
+	}
+	return *decoded.shift, true
+}
+
+// The address of a PC-relative operand. E.g., the target of "B 0x146e10".
+func (decoded Decoded) Target() (uint64, bool) {
+	if decoded.target == nil {
+		return 0, false
+	}
+	return *decoded.target, true
+}
+
+// The registers read only to compute the address of a memory operand.
+// E.g., R13 of "MOVW R0, 0x8(R13)" but not R0 of "MOVW R0, (R0)".
+func (decoded Decoded) AddressSources() []Register {
+	return decoded.addresses
+}
+
+// The registers read as data rather than only to compute an address.
+func (decoded Decoded) DataSources() (registers []Register) {
+	for _, register := range decoded.sources {
+		if !slices.Contains(decoded.addresses, register) {
+			registers = append(registers, register)
+		}
+	}
+	return
+}
+
+func (decoded Decoded) IsLoad() bool {
+	return decoded.operation == OP_LOAD
+}
+
+func (decoded Decoded) IsStore() bool {
+	return decoded.operation == OP_STORE
+}
+
+// Checks if the instruction is a call (branch with link).
+func (decoded Decoded) IsCall() bool {
+	return decoded.operation == OP_CALL
+}
+
+// Checks if the instruction may transfer control elsewhere than the next instruction
+// without linking. This includes writes to the PC such as "POP [R15]".
+func (decoded Decoded) IsBranch() bool {
+	return decoded.operation == OP_BRANCH || !decoded.IsCall() && slices.Contains(decoded.destinations, R15)
+}
+
+// Checks if the instruction returns to the caller, i.e., writes the link register
+// or a value loaded from the stack to the PC. E.g., "RET", "POP [R15]", or "ADD $0, R14, R15".
+func (decoded Decoded) IsReturn() bool {
+	if !decoded.IsBranch() {
+		return false
+	}
+
+	if decoded.mnemonic == "BX" || decoded.operation == OP_DATA || decoded.operation == OP_MOV {
+		return slices.Contains(decoded.sources, R14)
+	}
+
+	if memory, ok := decoded.Memory(); ok && decoded.operation == OP_LOAD {
+		return memory.Base == R13
+	}
+
+	return decoded.mnemonic == "POP"
+}
+
+func registerOf(reg armasm.Reg) (Register, bool) {
+	if reg > armasm.R15 {
+		return Register(""), false
+	}
+	return Register(fmt.Sprintf("R%d", reg)), true
+}
+
+func operationOf(mnemonic string) Operation {
+	switch mnemonic {
+	case "MOV", "MOVW", "MOVT", "MVN":
+		return OP_MOV
+	case "CMP", "CMN", "TST", "TEQ":
+		return OP_COMPARE
+	case "B", "BX", "BXJ":
+		return OP_BRANCH
+	case "BL", "BLX":
+		return OP_CALL
+	case "POP", "SWP":
+		return OP_LOAD
+	case "PUSH":
+		return OP_STORE
+	case "SVC", "BKPT", "NOP", "YIELD", "WFE", "WFI", "SEV", "DBG":
+		return OP_SYSTEM
+	}
+
+	switch {
+	case strings.HasPrefix(mnemonic, "LDR"), strings.HasPrefix(mnemonic, "LDM"):
+		return OP_LOAD
+	case strings.HasPrefix(mnemonic, "STR"), strings.HasPrefix(mnemonic, "STM"):
+		return OP_STORE
+	case strings.HasPrefix(mnemonic, "V"):
+		return OP_FLOAT
+	}
+
+	return OP_DATA
+}
+
+// The number of leading register arguments which are written in ARM manual order.
+func writtenArguments(mnemonic string) int {
+	switch mnemonic {
+	case "CMP", "CMN", "TST", "TEQ",
+		"B", "BX", "BXJ", "BL", "BLX", "PUSH", "POP", "MSR", "VMSR", "VSTR",
+		"SVC", "BKPT", "NOP", "YIELD", "WFE", "WFI", "SEV", "DBG":
+		return 0
+	case "UMULL", "SMULL", "UMLAL", "SMLAL", "UMAAL", "SMLALD", "SMLSLD",
+		"SMLALBB", "SMLALBT", "SMLALTB", "SMLALTT", "LDRD", "LDREXD":
+		return 2
+	}
+
+	switch {
+	case strings.HasPrefix(mnemonic, "STREX"):
+		// The first argument receives the status of the exclusive store.
+		return 1
+	case strings.HasPrefix(mnemonic, "STR"), strings.HasPrefix(mnemonic, "STM"), strings.HasPrefix(mnemonic, "LDM"):
+		return 0
+	}
+
+	return 1
+}
+
+// The number of bytes accessed by a load or store.
+func widthOf(mnemonic string) byte {
+	switch mnemonic {
+	case "LDRB", "LDRSB", "LDRBT", "LDRSBT", "LDREXB", "STRB", "STRBT", "STREXB", "SWPB":
+		return 1
+	case "LDRH", "LDRSH", "LDRHT", "LDRSHT", "LDREXH", "STRH", "STRHT", "STREXH":
+		return 2
+	case "LDRD", "LDREXD", "STRD", "STREXD":
+		return 8
+	}
+	return 4
+}
@@ -0,0 +1,164 @@
+package arm
+
+import (
+	"testing"
+
+	"github.com/hyperproperties/gorrupt/pkg/obj"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		description  string
+		instruction  obj.Instruction
+		operation    Operation
+		mnemonic     string
+		condition    Condition
+		destinations []Register
+		sources      []Register
+		data         []Register
+		memory       *Memory
+		immediate    *int64
+		target       *uint64
+		branch       bool
+		call         bool
+		ret          bool
+	}{
+		{
+			description:  "literal pool load",
+			instruction:  obj.NewInstruction("abi.go:58", 0x1106c, 0xe59f0038, "MOVW 0x38(R15), R0"),
+			operation:    OP_LOAD,
+			mnemonic:     "LDR",
+			condition:    AL,
+			destinations: []Register{R0},
+			sources:      []Register{R15},
+			memory:       &Memory{Base: R15, Offset: 0x38, Width: 4},
+		},
+		{
+			description: "store to stack",
+			instruction: obj.NewInstruction("abi.go:58", 0x11078, 0xe58d0008, "MOVW R0, 0x8(R13)"),
+			operation:   OP_STORE,
+			mnemonic:    "STR",
+			condition:   AL,
+			sources:     []Register{R0, R13},
+			data:        []Register{R0},
+			memory:      &Memory{Base: R13, Offset: 0x8, Width: 4},
+		},
+		{
+			description:  "indexed byte load",
+			instruction:  obj.NewInstruction("verify_pin.go:62", 0x146e5c, 0xe7d23000, "MOVBU (R2)(R0), R3"),
+			operation:    OP_LOAD,
+			mnemonic:     "LDRB",
+			condition:    AL,
+			destinations: []Register{R3},
+			sources:      []Register{R2, R0},
+			memory:       &Memory{Base: R2, Index: R0, Width: 1},
+		},
+		{
+			description:  "move immediate",
+			instruction:  obj.NewInstruction("verify_pin.go:39", 0x146df0, 0xe3a00003, "MOVW $3, R0"),
+			operation:    OP_MOV,
+			mnemonic:     "MOV",
+			condition:    AL,
+			destinations: []Register{R0},
+			immediate:    ptr(int64(3)),
+		},
+		{
+			description: "compare",
+			instruction: obj.NewInstruction("verify_pin.go:60", 0x146e2c, 0xe15d0001, "CMP R1, R13"),
+			operation:   OP_COMPARE,
+			mnemonic:    "CMP",
+			condition:   AL,
+			sources:     []Register{R13, R1},
+			data:        []Register{R13, R1},
+		},
+		{
+			description: "conditional branch",
+			instruction: obj.NewInstruction("verify_pin.go:60", 0x146e30, 0x9a000018, "B.LS 0x146e98"),
+			operation:   OP_BRANCH,
+			mnemonic:    "B",
+			condition:   LS,
+			target:      ptr(uint64(0x146e98)),
+			branch:      true,
+		},
+		{
+			description:  "call",
+			instruction:  obj.NewInstruction("verify_pin.go:72", 0x146f2c, 0xebffffbd, "BL pkg.PINCompare(SB)"),
+			operation:    OP_CALL,
+			mnemonic:     "BL",
+			condition:    AL,
+			destinations: []Register{R14},
+			target:       ptr(uint64(0x146e28)),
+			call:         true,
+		},
+		{
+			description:  "return with stack adjustment",
+			instruction:  obj.NewInstruction("verify_pin.go:74", 0x146f50, 0xe49df014, "RET #20"),
+			operation:    OP_LOAD,
+			mnemonic:     "LDR",
+			condition:    AL,
+			destinations: []Register{R15, R13},
+			sources:      []Register{R13},
+			memory:       &Memory{Base: R13, Offset: 20, Addressing: PostIndexAddressing, Width: 4},
+			branch:       true,
+			ret:          true,
+		},
+		{
+			description:  "return through link register",
+			instruction:  obj.NewInstruction("verify_pin.go:36", 0x146e18, 0xe28ef000, "ADD $0, R14, R15"),
+			operation:    OP_DATA,
+			mnemonic:     "ADD",
+			condition:    AL,
+			destinations: []Register{R15},
+			sources:      []Register{R14},
+			data:         []Register{R14},
+			immediate:    ptr(int64(0)),
+			branch:       true,
+			ret:          true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			decoded, err := Decode(tt.instruction)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.operation, decoded.Operation())
+			assert.Equal(t, tt.mnemonic, decoded.Mnemonic())
+			assert.Equal(t, tt.condition, decoded.Condition())
+			assert.ElementsMatch(t, tt.destinations, decoded.Destinations())
+			assert.ElementsMatch(t, tt.sources, decoded.Sources())
+			assert.ElementsMatch(t, tt.data, decoded.DataSources())
+
+			memory, ok := decoded.Memory()
+			assert.Equal(t, tt.memory != nil, ok)
+			if tt.memory != nil {
+				assert.Equal(t, *tt.memory, memory)
+			}
+
+			immediate, ok := decoded.Immediate()
+			assert.Equal(t, tt.immediate != nil, ok)
+			if tt.immediate != nil {
+				assert.Equal(t, *tt.immediate, immediate)
+			}
+
+			target, ok := decoded.Target()
+			assert.Equal(t, tt.target != nil, ok)
+			if tt.target != nil {
+				assert.Equal(t, *tt.target, target)
+			}
+
+			assert.Equal(t, tt.branch, decoded.IsBranch())
+			assert.Equal(t, tt.call, decoded.IsCall())
+			assert.Equal(t, tt.ret, decoded.IsReturn())
+		})
+	}
+}
+
+func TestDecodeUnknown(t *testing.T) {
+	_, err := Decode(obj.NewInstruction("verify_pin.go:36", 0x146e20, 0xffffffff, "?"))
+	assert.ErrorIs(t, err, ErrUnknownInstruction)
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
@@ -0,0 +1,37 @@
+package arm
+
+import (
+	"github.com/hyperproperties/gorrupt/pkg/fi"
+	"github.com/hyperproperties/gorrupt/pkg/obj"
+)
+
+var _ fi.FlowClassifier = (*FlowClassifier)(nil)
+
+type FlowClassifier struct{}
+
+func NewFlowClassifier() FlowClassifier {
+	return FlowClassifier{}
+}
+
+// Classifies the flow from the decoded instruction. Undecodable instructions are sequential.
+func (classifier FlowClassifier) Flow(instruction obj.Instruction) (fi.Flow, fi.PC) {
+	decoded, err := Decode(instruction)
+	if err != nil {
+		return fi.SequentialFlow, 0
+	}
+
+	target, _ := decoded.Target()
+	switch {
+	case decoded.IsCall():
+		return fi.CallFlow, fi.PC(target)
+	case decoded.IsReturn() && !decoded.IsConditional():
+		return fi.ReturnFlow, 0
+	case decoded.IsBranch() && decoded.IsConditional():
+		// A conditional return is a conditional branch to an unknown target.
+		return fi.ConditionalFlow, fi.PC(target)
+	case decoded.IsBranch():
+		return fi.JumpFlow, fi.PC(target)
+	}
+
+	return fi.SequentialFlow, 0
+}
@@ -0,0 +1,110 @@
+package arm
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/hyperproperties/gorrupt/pkg/fi"
+	"github.com/hyperproperties/gorrupt/pkg/obj"
+	"github.com/stretchr/testify/assert"
+)
+
+// A function with a stack check, a conditional return, and a call.
+var function = []obj.Instruction{
+	obj.NewInstruction("main.go:1", 0x1000, 0xe59a1008, "MOVW 8(R10), R1"),
+	obj.NewInstruction("main.go:1", 0x1004, 0xe15d0001, "CMP R1, R13"),
+	obj.NewInstruction("main.go:1", 0x1008, 0x9a000003, "B.LS 0x101c"),
+	obj.NewInstruction("main.go:2", 0x100c, 0xe3a00003, "MOVW $3, R0"),
+	obj.NewInstruction("main.go:3", 0x1010, 0xe3500000, "CMP $0, R0"),
+	obj.NewInstruction("main.go:3", 0x1014, 0x0affffff, "B.EQ 0x1018"),
+	obj.NewInstruction("main.go:4", 0x1018, 0xe28ef000, "RET"),
+	obj.NewInstruction("main.go:1", 0x101c, 0xe58d0004, "MOVW R0, 0x4(R13)"),
+	obj.NewInstruction("main.go:1", 0x1020, 0xeb0003f6, "BL 0x2000"),
+	obj.NewInstruction("main.go:1", 0x1024, 0xeafffff5, "B 0x1000"),
+}
+
+func TestCFG(t *testing.T) {
+	cfg := fi.NewCFG(function, NewFlowClassifier())
+
+	blocks := cfg.Blocks()
+	assert.Len(t, blocks, 4)
+
+	starts := make([]fi.PC, len(blocks))
+	for idx := range blocks {
+		starts[idx] = blocks[idx].Start()
+	}
+	assert.Equal(t, []fi.PC{0x1000, 0x100c, 0x1018, 0x101c}, starts)
+
+	assert.Equal(t, fi.ConditionalFlow, blocks[0].Flow())
+	assert.Equal(t, fi.ConditionalFlow, blocks[1].Flow())
+	assert.Equal(t, fi.ReturnFlow, blocks[2].Flow())
+	assert.Equal(t, fi.JumpFlow, blocks[3].Flow())
+
+	successors := cfg.Successors(blocks[0])
+	assert.Len(t, successors, 2)
+	assert.Equal(t, fi.TakenEdge, successors[0].Kind())
+	assert.Equal(t, fi.NewTransition(0x1008, 0x101c), successors[0].Transition)
+	assert.True(t, successors[0].IsConditional())
+	assert.Equal(t, 3, successors[0].To())
+	assert.Equal(t, fi.FallthroughEdge, successors[1].Kind())
+	assert.Equal(t, fi.NewTransition(0x1008, 0x100c), successors[1].Transition)
+
+	predecessors := cfg.Predecessors(blocks[0])
+	assert.Len(t, predecessors, 1)
+	assert.Equal(t, fi.NewTransition(0x1024, 0x1000), predecessors[0].Transition)
+	assert.False(t, predecessors[0].IsConditional())
+
+	exits := cfg.Exits()
+	assert.Len(t, exits, 1)
+	assert.Equal(t, fi.PC(0x1018), exits[0].Start())
+
+	assert.Equal(t, []fi.Transition{fi.NewTransition(0x1020, 0x2000)}, cfg.Calls())
+
+	block, ok := cfg.Block(0x1010)
+	assert.True(t, ok)
+	assert.Equal(t, 1, block.Index())
+
+	assert.Equal(t, []fi.Transition{fi.NewTransition(0x1008, 0x101c)}, cfg.Into(0x101c))
+	assert.Equal(t, []fi.Transition{fi.NewTransition(0x100c, 0x1010)}, cfg.Into(0x1010))
+	assert.Equal(t, []fi.Transition{fi.NewTransition(0x1010, 0x1014)}, cfg.From(0x1010))
+	assert.Empty(t, cfg.From(0x1018))
+}
+
+func TestCFGEmpty(t *testing.T) {
+	cfg := fi.NewCFG(nil, NewFlowClassifier())
+	_, ok := cfg.Entry()
+	assert.False(t, ok)
+	assert.Empty(t, cfg.Blocks())
+}
+
+func TestBFRFlowSearch(t *testing.T) {
+	targets := NewBFRFlowSearch().Instructions(function)
+
+	// R0 is stored after the taken edge of the stack check and not after the return.
+	assert.Contains(t, targets, fi.NewBFRTarget(fi.NewTransition(0x1008, 0x101c), 0))
+	assert.NotContains(t, targets, fi.NewBFRTarget(fi.NewTransition(0x1018, 0x101c), 0))
+	// The link register is read before the return and is unknown after it.
+	assert.Contains(t, targets, fi.NewBFRTarget(fi.NewTransition(0x1014, 0x1018), 14))
+	// R1 is written after the first instruction which is entered from anywhere.
+	assert.Contains(t, targets, fi.NewBFRTarget(fi.NewTransition(0x1000, 0x1004), 1))
+	// The base registers of the load and store are attacked on the transitions into them.
+	assert.Contains(t, targets, fi.NewBFRTarget(fi.NewTransition(0, 0x1000), 10))
+	assert.Contains(t, targets, fi.NewBFRTarget(fi.NewTransition(0x1008, 0x101c), 13))
+}
+
+// The instructions of the function of VerifyPIN_0 in its prebuilt binary.
+func verifyPINFunction(t *testing.T, name string) []obj.Instruction {
+	t.Helper()
+
+	dump, err := obj.LoadFile("../../../examples/fissc/binaries/VerifyPIN_0_1/2-binary")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dump.FirstFunctionInPackage("github.com/hyperproperties/gorrupt/examples/fissc/VerifyPIN_0/pkg", name).Instructions()
+}
+
+func TestBFRFlowSearchUnique(t *testing.T) {
+	// The registers read at the head of the loop are attacked on both edges into it.
+	targets := NewBFRFlowSearch().Instructions(verifyPINFunction(t, "PINCompare"))
+	assert.Equal(t, unique(slices.Clone(targets)), targets)
+}
@@ -0,0 +1,201 @@
+package arm
+
+import (
+	"github.com/hyperproperties/gorrupt/pkg/fi"
+	"github.com/hyperproperties/gorrupt/pkg/obj"
+)
+
+// A set of the registers R0 to R15 where bit i is set if Ri is in the set.
+type registers uint16
+
+const allRegisters = registers(0xffff)
+
+func registersOf(list ...Register) (set registers) {
+	for _, register := range list {
+		if index, err := register.Index(); err == nil {
+			set |= 1 << index
+		}
+	}
+	return
+}
+
+func (set registers) contains(register Register) bool {
+	index, err := register.Index()
+	return err == nil && set&(1<<index) != 0
+}
+
+func (set registers) list() (list []Register) {
+	for _, register := range []Register{R0, R1, R2, R3, R4, R5, R6, R7, R8, R9, R10, R11, R12, R13, R14, R15} {
+		if set.contains(register) {
+			list = append(list, register)
+		}
+	}
+	return
+}
+
+var (
+	// Go passes arguments and results on the stack (ABI0) and preserves only the goroutine (R10)
+	// and stack pointer (R13) across calls. The closure context (R7) may be read by the callee.
+	callUses    = registersOf(R7, R10, R13)
+	callDefines = registersOf(R0, R1, R2, R3, R4, R5, R6, R7, R8, R9, R11, R12, R14)
+	// The registers read by the caller after a return.
+	returnUses = registersOf(R10, R13)
+)
+
+// The registers live before and after each instruction of a single function.
+// A register is live if its value may be read before it is written.
+type Liveness struct {
+	cfg     fi.CFG
+	in, out map[fi.PC]registers
+	// The registers read by each instruction and those of them which are operands.
+	uses, reads map[fi.PC]registers
+}
+
+// Computes the liveness by a backwards dataflow analysis over the CFG of the instructions.
+// Undecodable instructions are assumed to read every register and conditional instructions
+// to write none of them. No register is live in the blocks which are not reachable.
+func NewLiveness(instructions []obj.Instruction) Liveness {
+	liveness := Liveness{
+		cfg:   fi.NewCFG(instructions, NewFlowClassifier()),
+		in:    make(map[fi.PC]registers, len(instructions)),
+		out:   make(map[fi.PC]registers, len(instructions)),
+		uses:  make(map[fi.PC]registers, len(instructions)),
+		reads: make(map[fi.PC]registers, len(instructions)),
+	}
+
+	defines := make(map[fi.PC]registers, len(instructions))
+	for _, instruction := range instructions {
+		pc := fi.PC(instruction.Offset())
+		liveness.uses[pc], defines[pc] = liveness.effect(instruction)
+		if decoded, err := Decode(instruction); err == nil {
+			liveness.reads[pc] = registersOf(decoded.Sources()...)
+		}
+	}
+
+	blocks := liveness.cfg.Blocks()
+	for changed := true; changed; {
+		changed = false
+
+		for idx := len(blocks) - 1; idx >= 0; idx-- {
+			block := blocks[idx]
+			if !block.IsReachable() {
+				continue
+			}
+
+			var live registers
+			for _, edge := range liveness.cfg.Successors(block) {
+				live |= liveness.in[edge.Destination()]
+			}
+			if block.IsExit() {
+				if block.Flow() == fi.ReturnFlow {
+					live |= returnUses
+				} else {
+					// Tail calls and jumps to unknown targets may read anything.
+					live |= allRegisters
+				}
+			}
+
+			instructions := block.Instructions()
+			for i := len(instructions) - 1; i >= 0; i-- {
+				pc := fi.PC(instructions[i].Offset())
+				liveness.out[pc] = live
+				live = liveness.uses[pc] | live&^defines[pc]
+				if liveness.in[pc] != live {
+					liveness.in[pc] = live
+					changed = true
+				}
+			}
+		}
+	}
+
+	return liveness
+}
+
+// The registers read and (unconditionally) written by the instruction.
+func (liveness Liveness) effect(instruction obj.Instruction) (uses, defines registers) {
+	decoded, err := Decode(instruction)
+	if err != nil {
+		return allRegisters, 0
+	}
+
+	uses = registersOf(decoded.Sources()...)
+	if !decoded.IsConditional() {
+		defines = registersOf(decoded.Destinations()...)
+	}
+
+	if decoded.IsCall() {
+		uses |= callUses
+		if !decoded.IsConditional() {
+			defines |= callDefines
+		}
+	}
+
+	return
+}
+
+func (liveness Liveness) CFG() fi.CFG {
+	return liveness.cfg
+}
+
+// The registers live before the instruction at the PC.
+func (liveness Liveness) In(pc fi.PC) []Register {
+	return liveness.in[pc].list()
+}
+
+// The registers live after the instruction at the PC.
+func (liveness Liveness) Out(pc fi.PC) []Register {
+	return liveness.out[pc].list()
+}
+
+// Checks if the register is live on the transition. A transition from an unknown
+// source is live before its destination and one to an unknown destination after its source.
+func (liveness Liveness) IsLive(register Register, transition fi.Transition) bool {
+	if destination := transition.Destination(); destination != 0 {
+		return liveness.in[destination].contains(register)
+	}
+	return liveness.out[transition.Source()].contains(register)
+}
+
+// Checks if a flip of the register on the transition has the same effect as a flip on a later transition
+// into an instruction reading the register. This is the case if control flows from the destination to the
+// reading instruction without branching and the instructions in between neither read nor write the register.
+func (liveness Liveness) IsDeferred(register Register, transition fi.Transition) bool {
+	visited := make(map[fi.PC]bool)
+	for pc := transition.Destination(); pc != 0 && !visited[pc]; {
+		visited[pc] = true
+		if liveness.reads[pc].contains(register) {
+			return pc != transition.Destination()
+		}
+		// The register is read implicitly, e.g., by a call or an undecodable instruction, or it is written.
+		if liveness.uses[pc].contains(register) || !liveness.out[pc].contains(register) {
+			return false
+		}
+
+		from := liveness.cfg.From(pc)
+		if block, _ := liveness.cfg.Block(pc); len(from) != 1 || (block.End() == pc && block.IsExit()) {
+			return false
+		}
+		pc = from[0].Destination()
+	}
+	return false
+}
+
+var _ fi.LinearSearcher[fi.BFRTarget] = (*BFRLivenessSearch)(nil)
+
+type BFRLivenessSearch struct{}
+
+func NewBFRLivenessSearch() BFRLivenessSearch {
+	return BFRLivenessSearch{}
+}
+
+// Searches for BFR targets like BFRFlowSearch but only yields those of registers which are live on entry
+// to the destination of the transition. A fault on a dead register is masked since it is written before
+// it is read, and no register is live in unreachable code such as literal pools. A flip which is deferred
+// to a later transition into a reading instruction is only yielded on that transition.
+// The instructions must be those of a single function.
+func (searcher BFRLivenessSearch) Instructions(instructions []obj.Instruction) []fi.BFRTarget {
+	liveness := NewLiveness(instructions)
+	return flowTargets(instructions, liveness.CFG(), func(register Register, transition fi.Transition) bool {
+		return liveness.IsLive(register, transition) && !liveness.IsDeferred(register, transition)
+	})
+}
@@ -0,0 +1,114 @@
+package arm
+
+import (
+	"testing"
+
+	"github.com/hyperproperties/gorrupt/pkg/fi"
+	"github.com/hyperproperties/gorrupt/pkg/obj"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLiveness(t *testing.T) {
+	liveness := NewLiveness(function)
+
+	tests := []struct {
+		description string
+		pc          fi.PC
+		in, out     []Register
+	}{
+		{
+			description: "return address and stored register are live at the entry",
+			pc:          0x1000,
+			in:          []Register{R0, R7, R10, R13, R14},
+			out:         []Register{R0, R1, R7, R10, R13, R14},
+		},
+		{
+			description: "compared register is dead after the comparison",
+			pc:          0x1010,
+			in:          []Register{R0, R10, R13, R14},
+			out:         []Register{R10, R13, R14},
+		},
+		{
+			description: "only goroutine and stack pointer are live after the return",
+			pc:          0x1018,
+			in:          []Register{R10, R13, R14},
+			out:         []Register{R10, R13},
+		},
+		{
+			description: "caller-saved registers are dead before a call",
+			pc:          0x1020,
+			in:          []Register{R7, R10, R13},
+			out:         []Register{R0, R7, R10, R13, R14},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			assert.Equal(t, tt.in, liveness.In(tt.pc))
+			assert.Equal(t, tt.out, liveness.Out(tt.pc))
+		})
+	}
+}
+
+func TestBFRLivenessSearch(t *testing.T) {
+	instructions := []obj.Instruction{
+		obj.NewInstruction("main.go:1", 0x2000, 0xe3a00003, "MOVW $3, R0"),
+		obj.NewInstruction("main.go:2", 0x2004, 0xe3a00004, "MOVW $4, R0"),
+		obj.NewInstruction("main.go:3", 0x2008, 0xe28ef000, "RET"),
+	}
+
+	// Both writes of R0 are dead since results are passed on the stack.
+	assert.Equal(t, []fi.BFRTarget{
+		fi.NewBFRTarget(fi.NewTransition(0x2004, 0x2008), 14),
+	}, NewBFRLivenessSearch().Instructions(instructions))
+
+	assert.Equal(t, []fi.BFRTarget{
+		fi.NewBFRTarget(fi.NewTransition(0x2000, 0x2004), 0),
+		fi.NewBFRTarget(fi.NewTransition(0x2004, 0x2008), 0),
+		fi.NewBFRTarget(fi.NewTransition(0x2004, 0x2008), 14),
+	}, NewBFRFlowSearch().Instructions(instructions))
+}
+
+func TestBFRLivenessSearchDeferred(t *testing.T) {
+	instructions := []obj.Instruction{
+		obj.NewInstruction("main.go:1", 0x2000, 0xe3a00003, "MOVW $3, R0"),
+		obj.NewInstruction("main.go:2", 0x2004, 0xe3a01004, "MOVW $4, R1"),
+		obj.NewInstruction("main.go:3", 0x2008, 0xe5cd0004, "MOVB R0, 0x4(R13)"),
+		obj.NewInstruction("main.go:3", 0x200c, 0xe28ef000, "RET"),
+	}
+
+	// The flip of R0 after it is written is the same as the flip before it is stored.
+	liveness := NewLiveness(instructions)
+	assert.True(t, liveness.IsDeferred(R0, fi.NewTransition(0x2000, 0x2004)))
+	assert.False(t, liveness.IsDeferred(R0, fi.NewTransition(0x2004, 0x2008)))
+
+	assert.Equal(t, []fi.BFRTarget{
+		fi.NewBFRTarget(fi.NewTransition(0x2004, 0x2008), 0),
+		fi.NewBFRTarget(fi.NewTransition(0x2004, 0x2008), 13),
+		fi.NewBFRTarget(fi.NewTransition(0x2008, 0x200c), 14),
+	}, NewBFRLivenessSearch().Instructions(instructions))
+}
+
+func TestBFRLivenessSearchReduction(t *testing.T) {
+	tests := []struct {
+		function string
+		// The maximum percentage of the targets of BFRLinearSearch.
+		percentage int
+	}{
+		{function: "VerifyPIN", percentage: 60},
+		{function: "PINCompare", percentage: 85},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.function, func(t *testing.T) {
+			instructions := verifyPINFunction(t, tt.function)
+			linear := NewBFRLinearSearch().Instructions(instructions)
+			flow := NewBFRFlowSearch().Instructions(instructions)
+			live := NewBFRLivenessSearch().Instructions(instructions)
+			assert.LessOrEqual(t, 100*len(live), tt.percentage*len(linear))
+
+			// Only the targets of the flow search are pruned.
+			assert.Subset(t, flow, live)
+		})
+	}
+}
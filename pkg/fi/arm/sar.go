@@ -0,0 +1,27 @@
+package arm
+
+import (
+	"github.com/hyperproperties/gorrupt/pkg/fi"
+	"github.com/hyperproperties/gorrupt/pkg/obj"
+)
+
+var _ fi.LinearSearcher[fi.SARTarget] = (*SARLinearSearch)(nil)
+
+// Stuck-at faults affect the same registers at the same transitions as bit-flips.
+type SARLinearSearch struct {
+	bfr BFRLinearSearch
+}
+
+func NewSARLinearSearch() SARLinearSearch {
+	return SARLinearSearch{
+		bfr: NewBFRLinearSearch(),
+	}
+}
+
+func (searcher SARLinearSearch) Instructions(instructions []obj.Instruction) (targets []fi.SARTarget) {
+	for _, target := range searcher.bfr.Instructions(instructions) {
+		targets = append(targets, fi.NewSARTarget(target.Transition, target.Register()))
+	}
+
+	return
+}
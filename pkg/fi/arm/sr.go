@@ -0,0 +1,27 @@
+package arm
+
+import (
+	"github.com/hyperproperties/gorrupt/pkg/fi"
+	"github.com/hyperproperties/gorrupt/pkg/obj"
+)
+
+var _ fi.LinearSearcher[fi.SRTarget] = (*SRLinearSearch)(nil)
+
+// Set/reset faults affect the same registers at the same transitions as bit-flips.
+type SRLinearSearch struct {
+	bfr BFRLinearSearch
+}
+
+func NewSRLinearSearch() SRLinearSearch {
+	return SRLinearSearch{
+		bfr: NewBFRLinearSearch(),
+	}
+}
+
+func (searcher SRLinearSearch) Instructions(instructions []obj.Instruction) (targets []fi.SRTarget) {
+	for _, target := range searcher.bfr.Instructions(instructions) {
+		targets = append(targets, fi.NewSRTarget(target.Transition, target.Register()))
+	}
+
+	return
+}
@@ -19,6 +19,9 @@ type Target interface {
 
 type TagetVisitor interface {
 	BFR(bfr BFRTarget)
+	BFM(bfm BFMTarget)
+	SAR(sar SARTarget)
+	SR(sr SRTarget)
 	IS(is ISTarget)
 	IC(ic ICTarget)
 }
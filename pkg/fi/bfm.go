@@ -0,0 +1,103 @@
+package fi
+
+import (
+	"fmt"
+
+	"github.com/hyperproperties/gorrupt/pkg/obj"
+)
+
+var _ Target = (*BFMTarget)(nil)
+
+type BFMTarget struct {
+	Transition
+	// The address of the first byte in memory to bit-flip.
+	address uint64
+	// The number of bytes at the address which can be bit-flipped.
+	width byte
+}
+
+func NewBFMTarget(transition Transition, address uint64, width byte) BFMTarget {
+	return BFMTarget{
+		transition,
+		address,
+		width,
+	}
+}
+
+func (target BFMTarget) Address() uint64 {
+	return target.address
+}
+
+func (target BFMTarget) Width() byte {
+	return target.width
+}
+
+func (target BFMTarget) Visit(visitor TagetVisitor) {
+	visitor.BFM(target)
+}
+
+var _ Attack = (*BFM)(nil)
+
+// Bit-Flip Memory (BFM):
+//
+//	The fault model for BFM is (bfm address width counter source destination mask)
+//	An example is (bfm 0x2703d8 1 0 0x146eb4 0x146eb8 1)
+type BFM struct {
+	BFMTarget
+	// The logical counter's initial value.
+	counter int32
+	// The mask describing what bits to flip starting from the address.
+	mask uint32
+}
+
+func NewBFM(address uint64, width byte, counter int32, source, destination PC, mask uint32) BFM {
+	return BFM{
+		BFMTarget{
+			Transition{
+				source,
+				destination,
+			},
+			address,
+			width,
+		},
+		counter,
+		mask,
+	}
+}
+
+func (bfm BFM) Address() uint64 {
+	return bfm.address
+}
+
+func (bfm BFM) Width() byte {
+	return bfm.width
+}
+
+func (bfm BFM) Counter() int32 {
+	return bfm.counter
+}
+
+func (bfm BFM) Source() PC {
+	return bfm.source
+}
+
+func (bfm BFM) Destination() PC {
+	return bfm.destination
+}
+
+func (bfm BFM) Mask() uint32 {
+	return bfm.mask
+}
+
+func (bfm BFM) WithMask(mask uint32) Attack {
+	bfm.mask = mask
+	return bfm
+}
+
+func (bfm BFM) String() string {
+	return fmt.Sprintf("bfm 0x%x %d %d 0x%x 0x%x %d", bfm.address, bfm.width, bfm.counter, bfm.source, bfm.destination, bfm.mask)
+}
+
+type BFMSearcher interface {
+	Instructions(instructions []obj.Instruction) []BFM
+}
@@ -21,6 +21,10 @@ func NewBFRTarget(transition Transition, register byte) BFRTarget {
 	}
 }
 
+func (target BFRTarget) Register() byte {
+	return target.register
+}
+
 func (target BFRTarget) Visit(visitor TagetVisitor) {
 	visitor.BFR(target)
 }
@@ -73,6 +77,11 @@ func (bfr BFR) Mask() uint32 {
 	return bfr.mask
 }
 
+func (bfr BFR) WithMask(mask uint32) Attack {
+	bfr.mask = mask
+	return bfr
+}
+
 func (bfr BFR) String() string {
 	return fmt.Sprintf("bfr %d %d 0x%x 0x%x %d", bfr.register, bfr.counter, bfr.source, bfr.destination, bfr.mask)
 }
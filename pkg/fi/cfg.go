@@ -1,5 +1,12 @@
 package fi
 
+import (
+	"cmp"
+	"slices"
+
+	"github.com/hyperproperties/gorrupt/pkg/obj"
+)
+
 type PC uint64
 
 type Transition struct {
@@ -13,4 +20,381 @@ func NewTransition(source, destination PC) Transition {
 		source,
 		destination,
 	}
-}
\ No newline at end of file
+}
+
+func (transition Transition) Source() PC {
+	return transition.source
+}
+
+func (transition Transition) Destination() PC {
+	return transition.destination
+}
+
+// How the control flows from an instruction.
+type Flow byte
+
+const (
+	// Continues with the next instruction.
+	SequentialFlow = Flow(iota)
+	// Calls the target and continues with the next instruction when it returns.
+	CallFlow
+	// Continues with the target.
+	JumpFlow
+	// Continues with either the target or the next instruction.
+	ConditionalFlow
+	// Leaves the function by returning to the caller.
+	ReturnFlow
+)
+
+// Classifies the control flow of instructions for a specific architecture.
+type FlowClassifier interface {
+	// The flow from the instruction and the target of calls and jumps.
+	// The target is "0" if it is unknown, e.g., for a branch to an address in a register.
+	Flow(instruction obj.Instruction) (Flow, PC)
+}
+
+type EdgeKind byte
+
+const (
+	// From the last instruction of a block to the instruction following it.
+	FallthroughEdge = EdgeKind(iota)
+	// From a jump or conditional branch to its target.
+	TakenEdge
+)
+
+// The edge between two basic blocks of a CFG.
+type Edge struct {
+	Transition
+	kind EdgeKind
+	// Whether the edge is only followed under some condition.
+	conditional bool
+	// The indices of the blocks the edge goes from and to.
+	from, to int
+}
+
+func (edge Edge) Kind() EdgeKind {
+	return edge.kind
+}
+
+func (edge Edge) IsConditional() bool {
+	return edge.conditional
+}
+
+func (edge Edge) From() int {
+	return edge.from
+}
+
+func (edge Edge) To() int {
+	return edge.to
+}
+
+// A basic block is a sequence of instructions which is only entered at its first
+// instruction and only left after its last instruction.
+type Block struct {
+	index        int
+	instructions []obj.Instruction
+	// The flow from the last instruction.
+	flow Flow
+	// Whether control may leave the function from the block.
+	exit bool
+	// Whether control may reach the block from the entry of the function.
+	reachable bool
+	// The indices of the edges to and from the block.
+	predecessors, successors []int
+}
+
+func (block Block) Index() int {
+	return block.index
+}
+
+func (block Block) Instructions() []obj.Instruction {
+	return block.instructions
+}
+
+// The PC of the first instruction.
+func (block Block) Start() PC {
+	return PC(block.instructions[0].Offset())
+}
+
+// The PC of the last instruction.
+func (block Block) End() PC {
+	return PC(block.instructions[len(block.instructions)-1].Offset())
+}
+
+func (block Block) Flow() Flow {
+	return block.flow
+}
+
+// Checks if control may leave the function after the block, e.g., by a return,
+// a tail call, or a jump to an unknown target.
+func (block Block) IsExit() bool {
+	return block.exit
+}
+
+// Checks if control may reach the block from the entry. Blocks which are not reachable
+// are, e.g., the constants of literal pools following the last instruction of the function.
+func (block Block) IsReachable() bool {
+	return block.reachable
+}
+
+func (block Block) Contains(pc PC) bool {
+	return block.Start() <= pc && pc <= block.End()
+}
+
+// The control-flow graph (CFG) of the instructions of a single function.
+type CFG struct {
+	blocks []Block
+	edges  []Edge
+	// The call sites and their targets.
+	calls []Transition
+}
+
+// Constructs the CFG of the instructions of a single function. The instructions are sorted by their offset
+// and control never falls through a gap between two instructions, e.g., between two functions. However,
+// the instructions of adjacent functions cannot be told apart and must be given to separate CFGs.
+func NewCFG(instructions []obj.Instruction, classifier FlowClassifier) CFG {
+	var cfg CFG
+	if len(instructions) == 0 {
+		return cfg
+	}
+
+	instructions = slices.Clone(instructions)
+	slices.SortFunc(instructions, func(a, b obj.Instruction) int {
+		return cmp.Compare(a.Offset(), b.Offset())
+	})
+	instructions = slices.CompactFunc(instructions, func(a, b obj.Instruction) bool {
+		return a.Offset() == b.Offset()
+	})
+
+	indices := make(map[PC]int, len(instructions))
+	for idx, instruction := range instructions {
+		indices[PC(instruction.Offset())] = idx
+	}
+
+	flows := make([]Flow, len(instructions))
+	targets := make([]PC, len(instructions))
+	for idx, instruction := range instructions {
+		flows[idx], targets[idx] = classifier.Flow(instruction)
+		if flows[idx] == CallFlow {
+			cfg.calls = append(cfg.calls, NewTransition(PC(instruction.Offset()), targets[idx]))
+		}
+	}
+
+	// Whether control may fall through from each instruction to the instruction following it.
+	adjacent := make([]bool, len(instructions))
+	for idx := range len(instructions) - 1 {
+		adjacent[idx] = instructions[idx].End() == instructions[idx+1].Offset()
+	}
+
+	// The leaders are the first instructions of the blocks: The entry, the targets of jumps within
+	// the function, the instructions following jumps and returns, and the instructions following a gap.
+	leaders := make([]bool, len(instructions))
+	leaders[0] = true
+	for idx := range instructions {
+		if idx+1 < len(instructions) && !adjacent[idx] {
+			leaders[idx+1] = true
+		}
+		switch flows[idx] {
+		case JumpFlow, ConditionalFlow:
+			if target, ok := indices[targets[idx]]; ok {
+				leaders[target] = true
+			}
+			fallthrough
+		case ReturnFlow:
+			if idx+1 < len(instructions) {
+				leaders[idx+1] = true
+			}
+		}
+	}
+
+	blocks := make([]int, len(instructions))
+	for idx := range instructions {
+		if leaders[idx] {
+			cfg.blocks = append(cfg.blocks, Block{index: len(cfg.blocks)})
+		}
+		block := &cfg.blocks[len(cfg.blocks)-1]
+		block.instructions = append(block.instructions, instructions[idx])
+		blocks[idx] = block.index
+	}
+
+	last := -1
+	for idx := range cfg.blocks {
+		last += len(cfg.blocks[idx].instructions)
+		cfg.blocks[idx].flow = flows[last]
+
+		next := last + 1
+		target, internal := indices[targets[last]]
+		switch flows[last] {
+		case SequentialFlow, CallFlow:
+			if adjacent[last] {
+				cfg.connect(instructions, FallthroughEdge, false, last, next, blocks)
+			} else {
+				cfg.blocks[idx].exit = true
+			}
+		case JumpFlow:
+			if internal {
+				cfg.connect(instructions, TakenEdge, false, last, target, blocks)
+			} else {
+				cfg.blocks[idx].exit = true
+			}
+		case ConditionalFlow:
+			if internal {
+				cfg.connect(instructions, TakenEdge, true, last, target, blocks)
+			} else {
+				cfg.blocks[idx].exit = true
+			}
+			if adjacent[last] {
+				cfg.connect(instructions, FallthroughEdge, true, last, next, blocks)
+			} else {
+				cfg.blocks[idx].exit = true
+			}
+		case ReturnFlow:
+			cfg.blocks[idx].exit = true
+		}
+	}
+
+	// The blocks following a gap, e.g., the entry of another function, are entered by callers like the entry.
+	first := 0
+	for idx := range cfg.blocks {
+		if idx == 0 || !adjacent[first-1] {
+			cfg.reach(idx)
+		}
+		first += len(cfg.blocks[idx].instructions)
+	}
+
+	return cfg
+}
+
+// Marks the block and the blocks reachable from it as reachable.
+func (cfg *CFG) reach(block int) {
+	if cfg.blocks[block].reachable {
+		return
+	}
+	cfg.blocks[block].reachable = true
+	for _, edge := range cfg.blocks[block].successors {
+		cfg.reach(cfg.edges[edge].to)
+	}
+}
+
+func (cfg *CFG) connect(instructions []obj.Instruction, kind EdgeKind, conditional bool, from, to int, blocks []int) {
+	edge := Edge{
+		Transition:  NewTransition(PC(instructions[from].Offset()), PC(instructions[to].Offset())),
+		kind:        kind,
+		conditional: conditional,
+		from:        blocks[from],
+		to:          blocks[to],
+	}
+
+	index := len(cfg.edges)
+	cfg.edges = append(cfg.edges, edge)
+	cfg.blocks[edge.from].successors = append(cfg.blocks[edge.from].successors, index)
+	cfg.blocks[edge.to].predecessors = append(cfg.blocks[edge.to].predecessors, index)
+}
+
+func (cfg CFG) Blocks() []Block {
+	return cfg.blocks
+}
+
+func (cfg CFG) Edges() []Edge {
+	return cfg.edges
+}
+
+// The block containing the first instruction.
+func (cfg CFG) Entry() (Block, bool) {
+	if len(cfg.blocks) == 0 {
+		return Block{}, false
+	}
+	return cfg.blocks[0], true
+}
+
+// The blocks control may leave the function from.
+func (cfg CFG) Exits() (exits []Block) {
+	for _, block := range cfg.blocks {
+		if block.exit {
+			exits = append(exits, block)
+		}
+	}
+	return
+}
+
+// The block containing the instruction at the PC.
+func (cfg CFG) Block(pc PC) (Block, bool) {
+	idx, found := slices.BinarySearchFunc(cfg.blocks, pc, func(block Block, pc PC) int {
+		switch {
+		case block.End() < pc:
+			return -1
+		case block.Start() > pc:
+			return 1
+		}
+		return 0
+	})
+	if !found {
+		return Block{}, false
+	}
+	return cfg.blocks[idx], true
+}
+
+func (cfg CFG) Successors(block Block) (edges []Edge) {
+	for _, idx := range block.successors {
+		edges = append(edges, cfg.edges[idx])
+	}
+	return
+}
+
+func (cfg CFG) Predecessors(block Block) (edges []Edge) {
+	for _, idx := range block.predecessors {
+		edges = append(edges, cfg.edges[idx])
+	}
+	return
+}
+
+// The transitions from call sites to the called functions.
+func (cfg CFG) Calls() []Transition {
+	return cfg.calls
+}
+
+// The transitions within the function into the instruction at the PC.
+func (cfg CFG) Into(pc PC) (transitions []Transition) {
+	block, ok := cfg.Block(pc)
+	if !ok {
+		return
+	}
+
+	if block.Start() == pc {
+		for _, edge := range cfg.Predecessors(block) {
+			transitions = append(transitions, edge.Transition)
+		}
+		return
+	}
+
+	idx := slices.IndexFunc(block.instructions, func(instruction obj.Instruction) bool {
+		return PC(instruction.Offset()) == pc
+	})
+	if idx > 0 {
+		transitions = append(transitions, NewTransition(PC(block.instructions[idx-1].Offset()), pc))
+	}
+	return
+}
+
+// The transitions within the function from the instruction at the PC.
+func (cfg CFG) From(pc PC) (transitions []Transition) {
+	block, ok := cfg.Block(pc)
+	if !ok {
+		return
+	}
+
+	if block.End() == pc {
+		for _, edge := range cfg.Successors(block) {
+			transitions = append(transitions, edge.Transition)
+		}
+		return
+	}
+
+	idx := slices.IndexFunc(block.instructions, func(instruction obj.Instruction) bool {
+		return PC(instruction.Offset()) == pc
+	})
+	if idx >= 0 {
+		transitions = append(transitions, NewTransition(pc, PC(block.instructions[idx+1].Offset())))
+	}
+	return
+}
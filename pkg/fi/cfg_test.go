@@ -0,0 +1,187 @@
+package fi
+
+import (
+	"testing"
+
+	"github.com/hyperproperties/gorrupt/pkg/obj"
+	"github.com/stretchr/testify/assert"
+)
+
+// Classifies the flow of the instructions by their offset. Instructions without a flow are sequential.
+type stubClassifier map[uint64]struct {
+	flow   Flow
+	target PC
+}
+
+func (classifier stubClassifier) Flow(instruction obj.Instruction) (Flow, PC) {
+	flow := classifier[instruction.Offset()]
+	return flow.flow, flow.target
+}
+
+func instructionsAt(offsets ...uint64) []obj.Instruction {
+	instructions := make([]obj.Instruction, len(offsets))
+	for idx, offset := range offsets {
+		instructions[idx] = obj.NewInstruction("main.go:1", offset, 0, "")
+	}
+	return instructions
+}
+
+// A loop of the blocks [0x10, 0x14] and [0x18, 0x1c] which exits to the returning block [0x20] and is
+// followed by the unreachable block [0x24, 0x28] calling 0x1000.
+var loop = stubClassifier{
+	0x14: {ConditionalFlow, 0x20},
+	0x1c: {JumpFlow, 0x10},
+	0x20: {ReturnFlow, 0},
+	0x24: {CallFlow, 0x1000},
+	0x28: {ReturnFlow, 0},
+}
+
+func TestNewCFG(t *testing.T) {
+	tests := []struct {
+		description  string
+		instructions []obj.Instruction
+		classifier   stubClassifier
+		starts       []PC
+		edges        []Transition
+		exits        []PC
+		unreachable  []PC
+	}{
+		{
+			description:  "sequential",
+			instructions: instructionsAt(0x10, 0x14, 0x18),
+			classifier:   stubClassifier{},
+			starts:       []PC{0x10},
+			exits:        []PC{0x10},
+		},
+		{
+			description:  "loop",
+			instructions: instructionsAt(0x10, 0x14, 0x18, 0x1c, 0x20, 0x24, 0x28),
+			classifier:   loop,
+			starts:       []PC{0x10, 0x18, 0x20, 0x24},
+			edges: []Transition{
+				NewTransition(0x14, 0x20), NewTransition(0x14, 0x18), NewTransition(0x1c, 0x10),
+			},
+			exits:       []PC{0x20, 0x24},
+			unreachable: []PC{0x24},
+		},
+		{
+			description:  "not sorted",
+			instructions: instructionsAt(0x20, 0x28, 0x10, 0x1c, 0x14, 0x18, 0x24),
+			classifier:   loop,
+			starts:       []PC{0x10, 0x18, 0x20, 0x24},
+			edges: []Transition{
+				NewTransition(0x14, 0x20), NewTransition(0x14, 0x18), NewTransition(0x1c, 0x10),
+			},
+			exits:       []PC{0x20, 0x24},
+			unreachable: []PC{0x24},
+		},
+		{
+			description:  "duplicates",
+			instructions: instructionsAt(0x10, 0x14, 0x10, 0x14),
+			classifier:   stubClassifier{},
+			starts:       []PC{0x10},
+			exits:        []PC{0x10},
+		},
+		{
+			description:  "several functions",
+			instructions: instructionsAt(0x40, 0x44, 0x10, 0x14, 0x18),
+			classifier: stubClassifier{
+				0x14: {ConditionalFlow, 0x10},
+			},
+			starts: []PC{0x10, 0x18, 0x40},
+			edges: []Transition{
+				NewTransition(0x14, 0x10), NewTransition(0x14, 0x18),
+			},
+			exits: []PC{0x18, 0x40},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			cfg := NewCFG(tt.instructions, tt.classifier)
+
+			var starts, unreachable []PC
+			for _, block := range cfg.Blocks() {
+				starts = append(starts, block.Start())
+				if !block.IsReachable() {
+					unreachable = append(unreachable, block.Start())
+				}
+
+				// Every instruction of the block is found in it.
+				for _, instruction := range block.Instructions() {
+					found, ok := cfg.Block(PC(instruction.Offset()))
+					assert.True(t, ok)
+					assert.Equal(t, block.Index(), found.Index())
+				}
+			}
+			assert.Equal(t, tt.starts, starts)
+			assert.Equal(t, tt.unreachable, unreachable)
+
+			var edges []Transition
+			for _, edge := range cfg.Edges() {
+				edges = append(edges, edge.Transition)
+				assert.Equal(t, edge.Source(), cfg.Blocks()[edge.From()].End())
+				assert.Equal(t, edge.Destination(), cfg.Blocks()[edge.To()].Start())
+			}
+			assert.Equal(t, tt.edges, edges)
+
+			var exits []PC
+			for _, block := range cfg.Exits() {
+				exits = append(exits, block.Start())
+			}
+			assert.Equal(t, tt.exits, exits)
+		})
+	}
+}
+
+func TestCFGTransitions(t *testing.T) {
+	cfg := NewCFG(instructionsAt(0x10, 0x14, 0x18, 0x1c, 0x20, 0x24, 0x28), loop)
+
+	tests := []struct {
+		description string
+		pc          PC
+		into, from  []Transition
+	}{
+		{
+			description: "entry of the loop",
+			pc:          0x10,
+			into:        []Transition{NewTransition(0x1c, 0x10)},
+			from:        []Transition{NewTransition(0x10, 0x14)},
+		},
+		{
+			description: "conditional branch",
+			pc:          0x14,
+			into:        []Transition{NewTransition(0x10, 0x14)},
+			from:        []Transition{NewTransition(0x14, 0x20), NewTransition(0x14, 0x18)},
+		},
+		{
+			description: "fall through",
+			pc:          0x18,
+			into:        []Transition{NewTransition(0x14, 0x18)},
+			from:        []Transition{NewTransition(0x18, 0x1c)},
+		},
+		{
+			description: "return",
+			pc:          0x20,
+			into:        []Transition{NewTransition(0x14, 0x20)},
+		},
+		{
+			description: "after a return",
+			pc:          0x24,
+			from:        []Transition{NewTransition(0x24, 0x28)},
+		},
+		{
+			description: "outside the function",
+			pc:          0x2c,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			assert.Equal(t, tt.into, cfg.Into(tt.pc))
+			assert.Equal(t, tt.from, cfg.From(tt.pc))
+		})
+	}
+
+	assert.Equal(t, []Transition{NewTransition(0x24, 0x1000)}, cfg.Calls())
+}
@@ -12,6 +12,10 @@ func NewICTarget(pc PC) ICTarget {
 	return ICTarget{ pc }
 }
 
+func (target ICTarget) PC() PC {
+	return target.pc
+}
+
 func (target ICTarget) Visit(visitor TagetVisitor) {
 	visitor.IC(target)
 }
@@ -28,6 +32,15 @@ func NewIC(pc PC, mask uint32, counter int32) IC {
 	return IC{ NewICTarget(pc), mask, counter }
 }
 
+func (ic IC) Mask() uint32 {
+	return ic.mask
+}
+
+func (ic IC) WithMask(mask uint32) Attack {
+	ic.mask = mask
+	return ic
+}
+
 func (ic IC) String() string {
 	return fmt.Sprintf("ic %d %d %d", ic.pc, ic.mask, ic.counter)
 }
\ No newline at end of file
@@ -12,6 +12,10 @@ func NewISTarget(pc PC) ISTarget {
 	return ISTarget{ pc }
 }
 
+func (target ISTarget) PC() PC {
+	return target.pc
+}
+
 func (target ISTarget) Visit(visitor TagetVisitor) {
 	visitor.IS(target)
 }
@@ -0,0 +1,196 @@
+package fi
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"math/rand/v2"
+	"strings"
+
+	"github.com/hyperproperties/gorrupt/pkg/iterx"
+)
+
+// A mask strategy generates the masks of the attacks on a target of "width" bits (at most 32).
+type MaskStrategy func(width int) iter.Seq[uint32]
+
+// Every mask flipping exactly one bit. This is the default strategy.
+func SingleBitMasks() MaskStrategy {
+	return HammingMasks(1)
+}
+
+// Every mask flipping exactly k bits.
+func HammingMasks(k int) MaskStrategy {
+	return func(width int) iter.Seq[uint32] {
+		return func(yield func(uint32) bool) {
+			for bits := range iterx.Combinations(k, min(width, 32)) {
+				var mask uint32
+				for _, bit := range bits {
+					mask |= 1 << bit
+				}
+				if !yield(mask) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Every mask flipping all bits of exactly one byte.
+func ByteMasks() MaskStrategy {
+	return func(width int) iter.Seq[uint32] {
+		return func(yield func(uint32) bool) {
+			for i := 0; i+8 <= min(width, 32); i += 8 {
+				if !yield(0xff << i) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// The mask with all bits set. The mask with no bits set is generated by ZeroMasks since it
+// flips nothing, e.g., all-zeros are the stuck-at-zero faults of SAR attacks with this mask.
+func SaturatedMasks() MaskStrategy {
+	return func(width int) iter.Seq[uint32] {
+		return func(yield func(uint32) bool) {
+			yield(ones(width))
+		}
+	}
+}
+
+// The mask with no bits set. The attacks with it are injected but leave the target unchanged
+// which makes them a control of the faults of the other masks.
+func ZeroMasks() MaskStrategy {
+	return func(width int) iter.Seq[uint32] {
+		return func(yield func(uint32) bool) {
+			yield(0)
+		}
+	}
+}
+
+// The first n distinct non-zero masks from a pseudo-random generator seeded with the seed.
+// The same seed and width always generate the same masks. There are at most 2^width-1 masks.
+func RandomMasks(seed uint64, n int) MaskStrategy {
+	return func(width int) iter.Seq[uint32] {
+		return func(yield func(uint32) bool) {
+			random := rand.New(rand.NewPCG(seed, uint64(width)))
+			n := uint64(min(n, int(ones(width))))
+			seen := make(map[uint32]struct{}, n)
+			for uint64(len(seen)) < n {
+				mask := random.Uint32() & ones(width)
+				if _, exists := seen[mask]; exists || mask == 0 {
+					continue
+				}
+				seen[mask] = struct{}{}
+				if !yield(mask) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// The maximum number of bits of exhaustive masks.
+const MaxExhaustiveBits = 16
+
+// Every non-zero mask of the lowest bits of the target. There are 2^bits-1 masks so the bits
+// must be in 1..MaxExhaustiveBits.
+func ExhaustiveMasks(bits int) MaskStrategy {
+	if bits < 1 || bits > MaxExhaustiveBits {
+		panic(fmt.Sprintf("exhaustive masks of %d bits", bits))
+	}
+	return func(width int) iter.Seq[uint32] {
+		return func(yield func(uint32) bool) {
+			for mask := uint32(1); mask <= ones(min(width, bits)); mask++ {
+				if !yield(mask) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// The masks of all the strategies in order where each mask is generated once.
+func JoinMasks(strategies ...MaskStrategy) MaskStrategy {
+	return func(width int) iter.Seq[uint32] {
+		return func(yield func(uint32) bool) {
+			seen := make(map[uint32]struct{})
+			for _, strategy := range strategies {
+				for mask := range strategy(width) {
+					if _, exists := seen[mask]; exists {
+						continue
+					}
+					seen[mask] = struct{}{}
+					if !yield(mask) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+var ErrUnknownMaskStrategy = errors.New("unknown mask strategy")
+
+// Parses a mask strategy by its name followed by its arguments. The strategies are
+// "single-bit", "hamming K", "byte", "saturated", "zero", "random SEED N", and "exhaustive BITS".
+func ParseMaskStrategy(str string) (MaskStrategy, error) {
+	fields := strings.Fields(str)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownMaskStrategy, str)
+	}
+
+	arguments := newArgumentReader(fields[1:])
+
+	// The number of arguments of every strategy.
+	expected := map[string]int{
+		"single-bit": 0, "hamming": 1, "byte": 0, "saturated": 0, "zero": 0, "random": 2, "exhaustive": 1,
+	}
+	name := fields[0]
+	n, exists := expected[name]
+	if !exists {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownMaskStrategy, name)
+	}
+	if err := arguments.expect(n); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	var strategy MaskStrategy
+	switch name {
+	case "single-bit":
+		strategy = SingleBitMasks()
+	case "hamming":
+		strategy = HammingMasks(int(arguments.uint(6)))
+	case "byte":
+		strategy = ByteMasks()
+	case "saturated":
+		strategy = SaturatedMasks()
+	case "zero":
+		strategy = ZeroMasks()
+	case "random":
+		strategy = RandomMasks(arguments.uint(64), int(arguments.uint(31)))
+	case "exhaustive":
+		bits := int(arguments.uint(8))
+		if arguments.err != nil {
+			break
+		}
+		if bits < 1 || bits > MaxExhaustiveBits {
+			return nil, fmt.Errorf("%s: %d bits are not in 1..%d", name, bits, MaxExhaustiveBits)
+		}
+		strategy = ExhaustiveMasks(bits)
+	}
+
+	if arguments.err != nil {
+		return nil, fmt.Errorf("%s: %w", name, arguments.err)
+	}
+
+	return strategy, nil
+}
+
+// The mask with the lowest "width" bits set.
+func ones(width int) uint32 {
+	if width >= 32 {
+		return 0xffffffff
+	}
+	return 1<<width - 1
+}
@@ -0,0 +1,150 @@
+package fi
+
+import (
+	"math/bits"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskStrategies(t *testing.T) {
+	tests := []struct {
+		description string
+		strategy    MaskStrategy
+		width       int
+		masks       int
+		weight      int
+	}{
+		{
+			description: "single bit",
+			strategy:    SingleBitMasks(),
+			width:       32,
+			masks:       32,
+			weight:      1,
+		},
+		{
+			description: "hamming weight 2",
+			strategy:    HammingMasks(2),
+			width:       32,
+			masks:       32 * 31 / 2,
+			weight:      2,
+		},
+		{
+			description: "bytes",
+			strategy:    ByteMasks(),
+			width:       16,
+			masks:       2,
+			weight:      8,
+		},
+		{
+			description: "exhaustive byte",
+			strategy:    ExhaustiveMasks(8),
+			width:       32,
+			masks:       255,
+		},
+		{
+			description: "exhaustive narrower than its bits",
+			strategy:    ExhaustiveMasks(16),
+			width:       4,
+			masks:       15,
+		},
+		{
+			description: "random",
+			strategy:    RandomMasks(42, 10),
+			width:       8,
+			masks:       10,
+		},
+		{
+			description: "random more masks than non-zero masks",
+			strategy:    RandomMasks(42, 10),
+			width:       2,
+			masks:       3,
+		},
+		{
+			description: "saturated",
+			strategy:    SaturatedMasks(),
+			width:       32,
+			masks:       1,
+			weight:      32,
+		},
+		{
+			description: "joined without duplicates",
+			strategy:    JoinMasks(SingleBitMasks(), ByteMasks(), SingleBitMasks()),
+			width:       16,
+			masks:       16 + 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			masks := slices.Collect(tt.strategy(tt.width))
+			assert.Len(t, masks, tt.masks)
+			seen := make(map[uint32]struct{})
+			for _, mask := range masks {
+				assert.NotZero(t, mask, "only ZeroMasks generates the zero mask")
+				assert.NotContains(t, seen, mask, "masks must be distinct")
+				seen[mask] = struct{}{}
+				assert.Zero(t, uint64(mask)>>tt.width, "mask must be within the width")
+				if tt.weight > 0 {
+					assert.Equal(t, tt.weight, bits.OnesCount32(mask))
+				}
+			}
+		})
+	}
+}
+
+func TestZeroMasks(t *testing.T) {
+	assert.Equal(t, []uint32{0}, slices.Collect(ZeroMasks()(32)))
+	assert.Equal(t, []uint32{0xffffffff, 0}, slices.Collect(JoinMasks(SaturatedMasks(), ZeroMasks())(32)))
+}
+
+func TestExhaustiveMasksBits(t *testing.T) {
+	assert.Panics(t, func() { ExhaustiveMasks(0) })
+	assert.Panics(t, func() { ExhaustiveMasks(MaxExhaustiveBits + 1) })
+	assert.NotPanics(t, func() { ExhaustiveMasks(MaxExhaustiveBits) })
+}
+
+func TestRandomMasksAreReproducible(t *testing.T) {
+	first := slices.Collect(RandomMasks(7, 16)(32))
+	second := slices.Collect(RandomMasks(7, 16)(32))
+	assert.Equal(t, first, second)
+}
+
+func TestParseMaskStrategy(t *testing.T) {
+	tests := []struct {
+		str   string
+		masks int
+		err   bool
+	}{
+		{str: "single-bit", masks: 8},
+		{str: "hamming 2", masks: 8 * 7 / 2},
+		{str: " byte ", masks: 1},
+		{str: "saturated", masks: 1},
+		{str: "random 7 5", masks: 5},
+		{str: "zero", masks: 1},
+		{str: "exhaustive 4", masks: 15},
+		{str: "exhaustive 16", masks: 255},
+		{str: "exhaustive", err: true},
+		{str: "exhaustive 17", err: true},
+		{str: "exhaustive 0", err: true},
+		{str: "exhaustive x", err: true},
+		{str: "", err: true},
+		{str: "glitch", err: true},
+		{str: "hamming", err: true},
+		{str: "hamming x", err: true},
+		{str: "byte 1", err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.str, func(t *testing.T) {
+			strategy, err := ParseMaskStrategy(tt.str)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, slices.Collect(strategy(8)), tt.masks)
+		})
+	}
+}
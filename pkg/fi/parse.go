@@ -0,0 +1,190 @@
+package fi
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+var ErrUnknownAttack = errors.New("unknown attack")
+
+// Parses a single attack in the qemu-fi format as written by its String method.
+// E.g., "bfr 10 0 0xdc3d4 0xdc3d8 8192" or "is 1404620 0".
+func ParseAttack(str string) (Attack, error) {
+	fields := strings.Fields(str)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownAttack, str)
+	}
+
+	arguments := newArgumentReader(fields[1:])
+
+	var attack Attack
+	switch model := fields[0]; model {
+	case "bfr":
+		if err := arguments.expect(5); err != nil {
+			return nil, fmt.Errorf("%s: %w", model, err)
+		}
+		attack = NewBFR(
+			byte(arguments.uint(8)), int32(arguments.int(32)),
+			PC(arguments.uint(64)), PC(arguments.uint(64)), uint32(arguments.uint(32)),
+		)
+	case "bfm":
+		if err := arguments.expect(6); err != nil {
+			return nil, fmt.Errorf("%s: %w", model, err)
+		}
+		attack = NewBFM(
+			arguments.uint(64), byte(arguments.uint(8)), int32(arguments.int(32)),
+			PC(arguments.uint(64)), PC(arguments.uint(64)), uint32(arguments.uint(32)),
+		)
+	case "sar":
+		if err := arguments.expect(6); err != nil {
+			return nil, fmt.Errorf("%s: %w", model, err)
+		}
+		attack = NewSAR(
+			byte(arguments.uint(8)), int32(arguments.int(32)),
+			PC(arguments.uint(64)), PC(arguments.uint(64)), uint32(arguments.uint(32)),
+			StuckAt(arguments.uint(1)),
+		)
+	case "sr":
+		if err := arguments.expect(5); err != nil {
+			return nil, fmt.Errorf("%s: %w", model, err)
+		}
+		attack = NewSR(
+			byte(arguments.uint(8)), int32(arguments.int(32)),
+			PC(arguments.uint(64)), PC(arguments.uint(64)), uint32(arguments.uint(32)),
+		)
+	case "is":
+		if err := arguments.expect(2); err != nil {
+			return nil, fmt.Errorf("%s: %w", model, err)
+		}
+		attack = NewIS(PC(arguments.uint(64)), int32(arguments.int(32)))
+	case "ic":
+		if err := arguments.expect(3); err != nil {
+			return nil, fmt.Errorf("%s: %w", model, err)
+		}
+		attack = NewIC(PC(arguments.uint(64)), uint32(arguments.uint(32)), int32(arguments.int(32)))
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownAttack, model)
+	}
+
+	if arguments.err != nil {
+		return nil, fmt.Errorf("%s: %w", fields[0], arguments.err)
+	}
+
+	return attack, nil
+}
+
+// Parses an attack plan as written by its String method. E.g., "[bfr 10 0 0xdc3d4 0xdc3d8 8192, is 1404620 0]".
+// The surrounding brackets are optional.
+func ParseAttackPlan(str string) (AttackPlan, error) {
+	str = strings.TrimSpace(str)
+	str = strings.TrimPrefix(str, "[")
+	str = strings.TrimSuffix(str, "]")
+
+	plan := AttackPlan{}
+	if strings.TrimSpace(str) == "" {
+		return plan, nil
+	}
+
+	for _, part := range strings.Split(str, ",") {
+		attack, err := ParseAttack(part)
+		if err != nil {
+			return nil, err
+		}
+		plan = append(plan, attack)
+	}
+
+	return plan, nil
+}
+
+// Reads an attack plan in the format of a qemu-fi attack file with one attack per line.
+func ReadAttackPlan(reader io.Reader) (AttackPlan, error) {
+	scanner := bufio.NewScanner(reader)
+	plan := AttackPlan{}
+	line := 0
+
+	for scanner.Scan() {
+		line++
+
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		attack, err := ParseAttack(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		plan = append(plan, attack)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// Reads the attack plan from a qemu-fi attack file. E.g., one written by the tester's Runner.Configure.
+func ParseFile(path string) (AttackPlan, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ReadAttackPlan(file)
+}
+
+// The arguments of an attack which are consumed in order.
+// The first error is kept and all consecutive reads return zero.
+type argumentReader struct {
+	fields []string
+	err    error
+}
+
+func newArgumentReader(fields []string) *argumentReader {
+	return &argumentReader{
+		fields: fields,
+	}
+}
+
+func (arguments *argumentReader) expect(n int) error {
+	if len(arguments.fields) != n {
+		return fmt.Errorf("expected %d arguments but got %d", n, len(arguments.fields))
+	}
+	return nil
+}
+
+func (arguments *argumentReader) next() (string, bool) {
+	if arguments.err != nil || len(arguments.fields) == 0 {
+		return "", false
+	}
+	field := arguments.fields[0]
+	arguments.fields = arguments.fields[1:]
+	return field, true
+}
+
+func (arguments *argumentReader) uint(bitSize int) uint64 {
+	field, ok := arguments.next()
+	if !ok {
+		return 0
+	}
+	value, err := strconv.ParseUint(field, 0, bitSize)
+	arguments.err = err
+	return value
+}
+
+func (arguments *argumentReader) int(bitSize int) int64 {
+	field, ok := arguments.next()
+	if !ok {
+		return 0
+	}
+	value, err := strconv.ParseInt(field, 0, bitSize)
+	arguments.err = err
+	return value
+}
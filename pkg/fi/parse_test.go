@@ -0,0 +1,105 @@
+package fi
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAttack(t *testing.T) {
+	tests := []struct {
+		description string
+		attack      Attack
+	}{
+		{
+			description: "bfr",
+			attack:      NewBFR(10, 0, 0xdc3d4, 0xdc3d8, 8192),
+		},
+		{
+			description: "bfm",
+			attack:      NewBFM(0x2703d8, 1, 0, 0x146eb4, 0x146eb8, 128),
+		},
+		{
+			description: "sar",
+			attack:      NewSAR(3, 1, 0x10, 0x14, 0xff, StuckAtOne),
+		},
+		{
+			description: "sr",
+			attack:      NewSR(3, 0, 0x10, 0x14, 0xffffffff),
+		},
+		{
+			description: "is",
+			attack:      NewIS(0x146eb4, 2),
+		},
+		{
+			description: "ic",
+			attack:      NewIC(0x146eb4, 1<<31, 0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			attack, err := ParseAttack(tt.attack.String())
+			assert.NoError(t, err)
+			assert.Equal(t, tt.attack, attack)
+		})
+	}
+}
+
+func TestParseAttackErrors(t *testing.T) {
+	for _, str := range []string{
+		"",
+		"xyz 1 2",
+		"bfr 10 0 0xdc3d4 0xdc3d8",
+		"bfr 10 0 0xdc3d4 0xdc3d8 mask",
+		"bfr 256 0 0xdc3d4 0xdc3d8 1",
+		"is 0x10",
+	} {
+		t.Run(str, func(t *testing.T) {
+			_, err := ParseAttack(str)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseAttackPlan(t *testing.T) {
+	tests := []struct {
+		description string
+		str         string
+		plan        AttackPlan
+	}{
+		{
+			description: "empty",
+			str:         "[]",
+			plan:        AttackPlan{},
+		},
+		{
+			description: "from test log",
+			str:         "[bfr 10 0 0xdc3d4 0xdc3d8 8192]",
+			plan:        AttackPlan{NewBFR(10, 0, 0xdc3d4, 0xdc3d8, 8192)},
+		},
+		{
+			description: "round trip",
+			str:         AttackPlan{NewIS(0x10, 0), NewIC(0x14, 4, 1)}.String(),
+			plan:        AttackPlan{NewIS(0x10, 0), NewIC(0x14, 4, 1)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			plan, err := ParseAttackPlan(tt.str)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.plan, plan)
+		})
+	}
+}
+
+func TestReadAttackPlan(t *testing.T) {
+	plan, err := ReadAttackPlan(strings.NewReader("bfr 10 0 0xdc3d4 0xdc3d8 8192\n\nis 16 0\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, AttackPlan{NewBFR(10, 0, 0xdc3d4, 0xdc3d8, 8192), NewIS(16, 0)}, plan)
+
+	_, err = ReadAttackPlan(strings.NewReader("is 16 0\nbfr\n"))
+	assert.ErrorContains(t, err, "line 2")
+}
@@ -1,15 +1,116 @@
 package fi
 
-import "iter"
+import (
+	"iter"
+
+	"github.com/hyperproperties/gorrupt/pkg/iterx"
+)
 
 var _ TagetVisitor = (*AttackPlanner)(nil)
 
+// Ordering describes how attacks are combined into a higher-order attack plan.
+type Ordering byte
+
+const (
+	// Every unordered set of distinct attacks. This is the default since
+	// qemu-fi triggers each attack of a plan independently of its position.
+	CombinationOrdering = Ordering(iota)
+	// Every ordered sequence of distinct attacks. E.g., an IS followed by an IC
+	// is considered different from the same IC followed by the IS.
+	ArrangementOrdering
+	// Every ordered sequence of attacks including those repeating an attack.
+	PermutationOrdering
+)
+
+type PlannerOption func(planner *AttackPlanner)
+
+// Plans consisting of exactly "order" attacks.
+func WithOrder(order int) PlannerOption {
+	return WithOrders(order, order)
+}
+
+// Plans consisting of at least "minimum" and at most "maximum" attacks.
+func WithOrders(minimum, maximum int) PlannerOption {
+	return func(planner *AttackPlanner) {
+		planner.minimum = minimum
+		planner.maximum = maximum
+	}
+}
+
+// The values a register is forced to by SR attacks. The default is a reset (0) and a set (0xffffffff).
+func WithRegisterValues(values ...uint32) PlannerOption {
+	return func(planner *AttackPlanner) {
+		planner.values = values
+	}
+}
+
+// The masks of all BFR, BFM, SAR, and IC attacks.
+func WithMasks(strategy MaskStrategy) PlannerOption {
+	return func(planner *AttackPlanner) {
+		planner.bfr = strategy
+		planner.bfm = strategy
+		planner.sar = strategy
+		planner.ic = strategy
+	}
+}
+
+func WithBFRMasks(strategy MaskStrategy) PlannerOption {
+	return func(planner *AttackPlanner) {
+		planner.bfr = strategy
+	}
+}
+
+func WithBFMMasks(strategy MaskStrategy) PlannerOption {
+	return func(planner *AttackPlanner) {
+		planner.bfm = strategy
+	}
+}
+
+func WithSARMasks(strategy MaskStrategy) PlannerOption {
+	return func(planner *AttackPlanner) {
+		planner.sar = strategy
+	}
+}
+
+func WithICMasks(strategy MaskStrategy) PlannerOption {
+	return func(planner *AttackPlanner) {
+		planner.ic = strategy
+	}
+}
+
+func WithOrdering(ordering Ordering) PlannerOption {
+	return func(planner *AttackPlanner) {
+		planner.ordering = ordering
+	}
+}
+
 type AttackPlanner struct {
 	attacks []Attack
+	// The range of attacks in a single plan (inclusive).
+	minimum, maximum int
+	// How attacks are combined in plans of more than one attack.
+	ordering Ordering
+	// The values registers are forced to.
+	values []uint32
+	// The mask strategies for each of the fault models with masks.
+	bfr, bfm, sar, ic MaskStrategy
 }
 
-func NewAttackPlanner() AttackPlanner {
-	return AttackPlanner{}
+func NewAttackPlanner(options ...PlannerOption) AttackPlanner {
+	planner := AttackPlanner{
+		minimum:  1,
+		maximum:  1,
+		ordering: CombinationOrdering,
+		values:   []uint32{0, 0xffffffff},
+		bfr:      SingleBitMasks(),
+		bfm:      SingleBitMasks(),
+		sar:      SingleBitMasks(),
+		ic:       SingleBitMasks(),
+	}
+	for idx := range options {
+		options[idx](&planner)
+	}
+	return planner
 }
 
 func (planner *AttackPlanner) Plan(targets ...Target) iter.Seq2[int, AttackPlan] {
@@ -23,33 +124,71 @@ func (planner *AttackPlanner) Plan(targets ...Target) iter.Seq2[int, AttackPlan]
 	for i := range targets {
 		targets[i].Visit(planner)
 	}
-	
+
+	attacks := planner.attacks
 	return func(yield func(int, AttackPlan) bool) {
 		i := 0
 
-		for _, attack := range planner.attacks {
-			i++
-			if !yield(i, AttackPlan{attack}) {
-				return
+		for order := max(planner.minimum, 1); order <= planner.maximum; order++ {
+			for plan := range iterx.Map(attacks, planner.indices(order, len(attacks))) {
+				i++
+				if !yield(i, AttackPlan(plan)) {
+					return
+				}
 			}
 		}
 	}
 }
 
+// The indices of the attacks for every plan of the order.
+func (planner *AttackPlanner) indices(order, attacks int) iter.Seq[[]int] {
+	switch planner.ordering {
+	case ArrangementOrdering:
+		return iterx.Arrangements(order, attacks)
+	case PermutationOrdering:
+		return iterx.Permutations(order, attacks)
+	default:
+		return iterx.Combinations(order, attacks)
+	}
+}
+
 func (planner *AttackPlanner) BFR(target BFRTarget) {
-	for i := 0; i < 32; i++ {
-		bfr := NewBFR(target.register, 0, target.source, target.destination, 1<<i)
+	for mask := range planner.bfr(32) {
+		bfr := NewBFR(target.register, 0, target.source, target.destination, mask)
 		planner.attacks = append(planner.attacks, bfr)
 	}
 }
 
+func (planner *AttackPlanner) BFM(target BFMTarget) {
+	for mask := range planner.bfm(8 * int(target.width)) {
+		bfm := NewBFM(target.address, target.width, 0, target.source, target.destination, mask)
+		planner.attacks = append(planner.attacks, bfm)
+	}
+}
+
+func (planner *AttackPlanner) SAR(target SARTarget) {
+	for mask := range planner.sar(32) {
+		for _, value := range []StuckAt{StuckAtZero, StuckAtOne} {
+			sar := NewSAR(target.register, 0, target.source, target.destination, mask, value)
+			planner.attacks = append(planner.attacks, sar)
+		}
+	}
+}
+
+func (planner *AttackPlanner) SR(target SRTarget) {
+	for _, value := range planner.values {
+		sr := NewSR(target.register, 0, target.source, target.destination, value)
+		planner.attacks = append(planner.attacks, sr)
+	}
+}
+
 func (planner *AttackPlanner) IS(target ISTarget) {
 	planner.attacks = append(planner.attacks, NewIS(target.pc, 0))
 }
 
 func (planner *AttackPlanner) IC(target ICTarget) {
-	for i := 0; i < 32; i++ {
-		ic := NewIC(target.pc, 1 << i, 0)
+	for mask := range planner.ic(32) {
+		ic := NewIC(target.pc, mask, 0)
 		planner.attacks = append(planner.attacks, ic)
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,123 @@
+package fi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttackPlannerOrders(t *testing.T) {
+	// Two IS targets and one IC target yields 2+32=34 single attacks.
+	targets := []Target{
+		NewISTarget(0x10), NewISTarget(0x14), NewICTarget(0x18),
+	}
+
+	tests := []struct {
+		description string
+		options     []PlannerOption
+		plans       int
+		order       int
+	}{
+		{
+			description: "single attacks by default",
+			options:     nil,
+			plans:       34,
+			order:       1,
+		},
+		{
+			description: "combinations of two",
+			options:     []PlannerOption{WithOrder(2)},
+			plans:       34 * 33 / 2,
+			order:       2,
+		},
+		{
+			description: "arrangements of two",
+			options:     []PlannerOption{WithOrder(2), WithOrdering(ArrangementOrdering)},
+			plans:       34 * 33,
+			order:       2,
+		},
+		{
+			description: "permutations of two",
+			options:     []PlannerOption{WithOrder(2), WithOrdering(PermutationOrdering)},
+			plans:       34 * 34,
+			order:       2,
+		},
+		{
+			description: "up to combinations of two",
+			options:     []PlannerOption{WithOrders(1, 2)},
+			plans:       34 + 34*33/2,
+			order:       0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			planner := NewAttackPlanner(tt.options...)
+			plans := 0
+			for _, plan := range planner.Plan(targets...) {
+				plans++
+				if tt.order > 0 {
+					assert.Len(t, plan, tt.order)
+				}
+			}
+			assert.Equal(t, tt.plans, plans)
+		})
+	}
+}
+
+func TestAttackPlannerNoTargets(t *testing.T) {
+	planner := NewAttackPlanner(WithOrder(2))
+	plans := 0
+	for _, plan := range planner.Plan() {
+		plans++
+		assert.Empty(t, plan)
+	}
+	assert.Equal(t, 1, plans)
+}
+
+func TestAttackPlannerRegisterFaults(t *testing.T) {
+	transition := NewTransition(0x10, 0x14)
+
+	tests := []struct {
+		description string
+		options     []PlannerOption
+		target      Target
+		attacks     []Attack
+	}{
+		{
+			description: "reset and set by default",
+			target:      NewSRTarget(transition, 3),
+			attacks: []Attack{
+				NewSR(3, 0, 0x10, 0x14, 0),
+				NewSR(3, 0, 0x10, 0x14, 0xffffffff),
+			},
+		},
+		{
+			description: "forced to values",
+			options:     []PlannerOption{WithRegisterValues(1)},
+			target:      NewSRTarget(transition, 3),
+			attacks: []Attack{
+				NewSR(3, 0, 0x10, 0x14, 1),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			planner := NewAttackPlanner(tt.options...)
+			var attacks []Attack
+			for _, plan := range planner.Plan(tt.target) {
+				attacks = append(attacks, plan...)
+			}
+			assert.Equal(t, tt.attacks, attacks)
+		})
+	}
+
+	planner := NewAttackPlanner()
+	plans := 0
+	for _, plan := range planner.Plan(NewSARTarget(transition, 3)) {
+		plans++
+		assert.IsType(t, SAR{}, plan[0])
+	}
+	assert.Equal(t, 64, plans)
+}
@@ -0,0 +1,95 @@
+package fi
+
+import "fmt"
+
+var _ Target = (*SARTarget)(nil)
+
+type SARTarget struct {
+	Transition
+	// The index of the register with stuck bits.
+	register byte
+}
+
+func NewSARTarget(transition Transition, register byte) SARTarget {
+	return SARTarget{
+		transition,
+		register,
+	}
+}
+
+func (target SARTarget) Register() byte {
+	return target.register
+}
+
+func (target SARTarget) Visit(visitor TagetVisitor) {
+	visitor.SAR(target)
+}
+
+// The value which the bits of a stuck-at fault are stuck at.
+type StuckAt byte
+
+const (
+	StuckAtZero = StuckAt(iota)
+	StuckAtOne
+)
+
+var _ Attack = (*SAR)(nil)
+
+// Stuck-At Register (SAR):
+//
+//	The fault model for SAR is (sar reg counter source destination mask value)
+//	An example is (sar 0 1 0xae800 0xae7cc 1 1)
+//	where the bits of the mask are stuck at the value (0 or 1).
+type SAR struct {
+	SARTarget
+	// The logical counter's initial value.
+	counter int32
+	// The mask describing what bits are stuck.
+	mask uint32
+	// The value the bits are stuck at.
+	value StuckAt
+}
+
+func NewSAR(register byte, counter int32, source, destination PC, mask uint32, value StuckAt) SAR {
+	return SAR{
+		SARTarget{
+			Transition{
+				source,
+				destination,
+			},
+			register,
+		},
+		counter,
+		mask,
+		value,
+	}
+}
+
+func (sar SAR) Counter() int32 {
+	return sar.counter
+}
+
+func (sar SAR) Source() PC {
+	return sar.source
+}
+
+func (sar SAR) Destination() PC {
+	return sar.destination
+}
+
+func (sar SAR) Mask() uint32 {
+	return sar.mask
+}
+
+func (sar SAR) WithMask(mask uint32) Attack {
+	sar.mask = mask
+	return sar
+}
+
+func (sar SAR) Value() StuckAt {
+	return sar.value
+}
+
+func (sar SAR) String() string {
+	return fmt.Sprintf("sar %d %d 0x%x 0x%x %d %d", sar.register, sar.counter, sar.source, sar.destination, sar.mask, sar.value)
+}
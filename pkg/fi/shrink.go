@@ -0,0 +1,156 @@
+package fi
+
+import "math/bits"
+
+var (
+	_ MaskedAttack = (*BFR)(nil)
+	_ MaskedAttack = (*BFM)(nil)
+	_ MaskedAttack = (*SAR)(nil)
+	_ MaskedAttack = (*IC)(nil)
+)
+
+// An attack on the bits of a mask.
+type MaskedAttack interface {
+	Attack
+	Mask() uint32
+	// The same attack on the bits of another mask.
+	WithMask(mask uint32) Attack
+}
+
+// Reports whether the failure of interest is reproduced under the attack plan.
+type Reproduces func(plan AttackPlan) (bool, error)
+
+// Shrinks a failing attack plan to a 1-minimal plan with 1-minimal masks.
+// Every plan is tested at most once.
+func Shrink(plan AttackPlan, reproduces Reproduces) (AttackPlan, error) {
+	reproduces = memoize(reproduces)
+
+	plan, err := MinimizePlan(plan, reproduces)
+	if err != nil {
+		return plan, err
+	}
+
+	return MinimizeMasks(plan, reproduces)
+}
+
+// Finds a 1-minimal subset of the attacks in the failing plan using delta debugging,
+// i.e., removing any single attack from the result no longer reproduces the failure.
+func MinimizePlan(plan AttackPlan, reproduces Reproduces) (AttackPlan, error) {
+	attacks, err := ddmin(plan, func(attacks []Attack) (bool, error) {
+		return reproduces(AttackPlan(attacks))
+	})
+	return AttackPlan(attacks), err
+}
+
+// Reduces the mask of every masked attack in the failing plan to a 1-minimal set of bits,
+// i.e., clearing any single bit of a mask in the result no longer reproduces the failure.
+func MinimizeMasks(plan AttackPlan, reproduces Reproduces) (AttackPlan, error) {
+	plan = append(AttackPlan{}, plan...)
+	for i := range plan {
+		masked, ok := plan[i].(MaskedAttack)
+		if !ok || bits.OnesCount32(masked.Mask()) < 2 {
+			continue
+		}
+
+		var flipped []uint32
+		for bit := range 32 {
+			if masked.Mask()&(1<<bit) != 0 {
+				flipped = append(flipped, 1<<bit)
+			}
+		}
+
+		flipped, err := ddmin(flipped, func(flipped []uint32) (bool, error) {
+			candidate := append(AttackPlan{}, plan...)
+			candidate[i] = masked.WithMask(union(flipped))
+			return reproduces(candidate)
+		})
+		if err != nil {
+			return plan, err
+		}
+		plan[i] = masked.WithMask(union(flipped))
+	}
+
+	return plan, nil
+}
+
+// Remembers the result of every tested plan by its textual representation.
+func memoize(reproduces Reproduces) Reproduces {
+	results := make(map[string]bool)
+	return func(plan AttackPlan) (bool, error) {
+		if ok, exists := results[plan.String()]; exists {
+			return ok, nil
+		}
+
+		ok, err := reproduces(plan)
+		if err != nil {
+			return false, err
+		}
+		results[plan.String()] = ok
+
+		return ok, nil
+	}
+}
+
+func union(masks []uint32) (mask uint32) {
+	for _, bit := range masks {
+		mask |= bit
+	}
+	return
+}
+
+// The delta debugging algorithm (ddmin) by Zeller and Hildebrandt. The items are assumed to be failing
+// and the result is a 1-minimal failing subset. The empty subset is never tested.
+func ddmin[T any](items []T, test func(items []T) (bool, error)) ([]T, error) {
+	granularity := 2
+	for len(items) >= 2 {
+		chunks := partition(items, granularity)
+
+		reduced := false
+		for _, chunk := range chunks {
+			ok, err := test(chunk)
+			if err != nil {
+				return items, err
+			}
+			if ok {
+				items, granularity, reduced = chunk, 2, true
+				break
+			}
+		}
+
+		// With two chunks the complements are the chunks themselves.
+		for i := 0; !reduced && granularity > 2 && i < len(chunks); i++ {
+			complement := make([]T, 0, len(items))
+			for j, chunk := range chunks {
+				if i != j {
+					complement = append(complement, chunk...)
+				}
+			}
+
+			ok, err := test(complement)
+			if err != nil {
+				return items, err
+			}
+			if ok {
+				items, granularity, reduced = complement, max(granularity-1, 2), true
+			}
+		}
+
+		if !reduced {
+			if granularity >= len(items) {
+				break
+			}
+			granularity = min(2*granularity, len(items))
+		}
+	}
+
+	return items, nil
+}
+
+// Partitions the items into n chunks of (almost) equal size.
+func partition[T any](items []T, n int) [][]T {
+	chunks := make([][]T, 0, n)
+	for i := range n {
+		chunks = append(chunks, items[i*len(items)/n:(i+1)*len(items)/n])
+	}
+	return chunks
+}
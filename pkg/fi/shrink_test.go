@@ -0,0 +1,94 @@
+package fi
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Reproduces the failure if the plan contains the attacks (by their strings) with at least the bits of their masks.
+func containing(attacks ...Attack) Reproduces {
+	return func(plan AttackPlan) (bool, error) {
+		for _, attack := range attacks {
+			found := slices.ContainsFunc(plan, func(candidate Attack) bool {
+				masked, ok := attack.(MaskedAttack)
+				if !ok {
+					return candidate.String() == attack.String()
+				}
+				other, ok := candidate.(MaskedAttack)
+				return ok && other.WithMask(masked.Mask()).String() == attack.String() &&
+					other.Mask()&masked.Mask() == masked.Mask()
+			})
+			if !found {
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+}
+
+func TestShrink(t *testing.T) {
+	tests := []struct {
+		description string
+		plan        AttackPlan
+		reproduces  Reproduces
+		shrunk      AttackPlan
+	}{
+		{
+			description: "single attack",
+			plan:        AttackPlan{NewIS(0x10, 0)},
+			reproduces:  containing(NewIS(0x10, 0)),
+			shrunk:      AttackPlan{NewIS(0x10, 0)},
+		},
+		{
+			description: "one contributing attack",
+			plan:        AttackPlan{NewIS(0x10, 0), NewIS(0x14, 0), NewIS(0x18, 0), NewIS(0x1c, 0), NewIS(0x20, 0)},
+			reproduces:  containing(NewIS(0x1c, 0)),
+			shrunk:      AttackPlan{NewIS(0x1c, 0)},
+		},
+		{
+			description: "two contributing attacks",
+			plan:        AttackPlan{NewIS(0x10, 0), NewIS(0x14, 0), NewIS(0x18, 0), NewIS(0x1c, 0), NewIS(0x20, 0), NewIS(0x24, 0)},
+			reproduces:  containing(NewIS(0x10, 0), NewIS(0x20, 0)),
+			shrunk:      AttackPlan{NewIS(0x10, 0), NewIS(0x20, 0)},
+		},
+		{
+			description: "wide mask",
+			plan:        AttackPlan{NewIS(0x10, 0), NewBFR(3, 0, 0x10, 0x14, 0xffffffff)},
+			reproduces:  containing(NewBFR(3, 0, 0x10, 0x14, 0x00100000)),
+			shrunk:      AttackPlan{NewBFR(3, 0, 0x10, 0x14, 0x00100000)},
+		},
+		{
+			description: "two contributing bits",
+			plan:        AttackPlan{NewIC(0x10, 0xff, 0), NewBFM(0x1000, 4, 0, 0x10, 0x14, 0xf0f0)},
+			reproduces:  containing(NewIC(0x10, 0x81, 0)),
+			shrunk:      AttackPlan{NewIC(0x10, 0x81, 0)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			tested := make(map[string]int)
+			shrunk, err := Shrink(tt.plan, func(plan AttackPlan) (bool, error) {
+				tested[plan.String()]++
+				assert.NotEmpty(t, plan)
+				return tt.reproduces(plan)
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.shrunk, shrunk)
+			for plan, count := range tested {
+				assert.Equal(t, 1, count, plan)
+			}
+		})
+	}
+}
+
+func TestShrinkError(t *testing.T) {
+	failure := errors.New("failure")
+	_, err := Shrink(AttackPlan{NewIS(0x10, 0), NewIS(0x14, 0)}, func(AttackPlan) (bool, error) {
+		return false, failure
+	})
+	assert.ErrorIs(t, err, failure)
+}
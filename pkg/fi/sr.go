@@ -0,0 +1,75 @@
+package fi
+
+import "fmt"
+
+var _ Target = (*SRTarget)(nil)
+
+type SRTarget struct {
+	Transition
+	// The index of the register to set.
+	register byte
+}
+
+func NewSRTarget(transition Transition, register byte) SRTarget {
+	return SRTarget{
+		transition,
+		register,
+	}
+}
+
+func (target SRTarget) Register() byte {
+	return target.register
+}
+
+func (target SRTarget) Visit(visitor TagetVisitor) {
+	visitor.SR(target)
+}
+
+var _ Attack = (*SR)(nil)
+
+// Set Register (SR):
+//
+//	The fault model for SR is (sr reg counter source destination value)
+//	An example is (sr 0 1 0xae800 0xae7cc 4294967295)
+//	where a reset of the register is a set to zero.
+type SR struct {
+	SRTarget
+	// The logical counter's initial value.
+	counter int32
+	// The value the register is forced to.
+	value uint32
+}
+
+func NewSR(register byte, counter int32, source, destination PC, value uint32) SR {
+	return SR{
+		SRTarget{
+			Transition{
+				source,
+				destination,
+			},
+			register,
+		},
+		counter,
+		value,
+	}
+}
+
+func (sr SR) Counter() int32 {
+	return sr.counter
+}
+
+func (sr SR) Source() PC {
+	return sr.source
+}
+
+func (sr SR) Destination() PC {
+	return sr.destination
+}
+
+func (sr SR) Value() uint32 {
+	return sr.value
+}
+
+func (sr SR) String() string {
+	return fmt.Sprintf("sr %d %d 0x%x 0x%x %d", sr.register, sr.counter, sr.source, sr.destination, sr.value)
+}
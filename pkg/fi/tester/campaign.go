@@ -0,0 +1,278 @@
+package tester
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"time"
+
+	"github.com/hyperproperties/gorrupt/pkg/fi"
+	"github.com/hyperproperties/gorrupt/pkg/fi/arm"
+	"github.com/hyperproperties/gorrupt/pkg/obj"
+	"gopkg.in/yaml.v3"
+)
+
+var ErrUnknownSearcher = errors.New("unknown searcher")
+
+// The names of the searchers of SearchTargets.
+var Searchers = []string{"bfr", "bfr-flow", "bfr-liveness", "bfm", "sar", "sr", "is", "ic"}
+
+// The targets of the searcher with the name in the instructions.
+func SearchTargets(name string, options ...InstructionsOption) (TargetsOption, error) {
+	switch name {
+	case "bfr":
+		return LinearSearchTargets(arm.NewBFRLinearSearch(), options...), nil
+	case "bfr-flow":
+		return FunctionSearchTargets(arm.NewBFRFlowSearch(), options...), nil
+	case "bfr-liveness":
+		return FunctionSearchTargets(arm.NewBFRLivenessSearch(), options...), nil
+	case "bfm":
+		return LinearSearchTargets(arm.NewBFMLinearSearch(), options...), nil
+	case "sar":
+		return LinearSearchTargets(arm.NewSARLinearSearch(), options...), nil
+	case "sr":
+		return LinearSearchTargets(arm.NewSRLinearSearch(), options...), nil
+	case "is":
+		return LinearSearchTargets(arm.NewISLinearSearch(), options...), nil
+	case "ic":
+		return LinearSearchTargets(arm.NewICLinearSearch(), options...), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownSearcher, name)
+	}
+}
+
+// A declarative campaign as read from a YAML (or JSON) file, e.g.,
+//
+//	package: github.com/hyperproperties/gorrupt/examples/fissc/VerifyPIN_0/pkg
+//	input: VerifyPINInput
+//	qemu: /path/to/qemu-arm
+//	functions: [VerifyPIN, PINCompare]
+//	searchers: [bfr, ic, is]
+//	masks: [single-bit, byte]
+//	timeout: 1m
+//	pool: 512
+//	batch: 4096
+type Campaign struct {
+	// The import path of the package under attack.
+	Package string `yaml:"package" json:"package"`
+	// The name of the package if it is not the last element of its import path.
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
+	// The name of the input type in the package whose method Call returns the output.
+	Input string `yaml:"input" json:"input"`
+	// The inputs (if they are not generated) which are encoded as JSON for the input type.
+	Inputs []any `yaml:"inputs,omitempty" json:"inputs,omitempty"`
+	// The path of the qemu with fault injection which is located by LocateQEMU if empty.
+	QEMU string `yaml:"qemu,omitempty" json:"qemu,omitempty"`
+	// The build environment which is GOARCH=arm and GOOS=linux by default.
+	Environment []string `yaml:"environment,omitempty" json:"environment,omitempty"`
+	// The functions in the package under attack.
+	Functions []string `yaml:"functions" json:"functions"`
+	// The searchers of targets in the functions, see Searchers.
+	Searchers []string `yaml:"searchers" json:"searchers"`
+	// The mask strategies which are joined, see fi.ParseMaskStrategy.
+	Masks []string `yaml:"masks,omitempty" json:"masks,omitempty"`
+	// The number of attacks in a plan which is 1 by default.
+	Order int `yaml:"order,omitempty" json:"order,omitempty"`
+	// The timeout of an execution which is a minute by default.
+	Timeout time.Duration `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+	// The size of the pool and batch of parallel quantifiers.
+	Pool  int   `yaml:"pool,omitempty" json:"pool,omitempty"`
+	Batch int32 `yaml:"batch,omitempty" json:"batch,omitempty"`
+	// The campaign directory of the generated and built files.
+	Directory string `yaml:"directory,omitempty" json:"directory,omitempty"`
+	// The name of the store in the campaign directory (if any), see WithStore.
+	Store string `yaml:"store,omitempty" json:"store,omitempty"`
+	// The boolean field of the output which reports detection by a countermeasure (if any).
+	Detector string `yaml:"detector,omitempty" json:"detector,omitempty"`
+	// The outcomes satisfying the property of fault tolerance.
+	Allow []Outcome `yaml:"allow,omitempty" json:"allow,omitempty"`
+	// The reports of the findings of the campaign.
+	Reports []CampaignReport `yaml:"reports,omitempty" json:"reports,omitempty"`
+}
+
+type CampaignReport struct {
+	// The format of the report, e.g., jsonl, csv, sarif, terminal, or html.
+	Format string `yaml:"format" json:"format"`
+	// The path of the report which is the standard output if empty.
+	Path string `yaml:"path,omitempty" json:"path,omitempty"`
+}
+
+// Loads the campaign from a YAML or JSON file. Unknown fields are errors.
+func LoadCampaign(name string) (Campaign, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return Campaign{}, err
+	}
+	defer file.Close()
+
+	campaign, err := ReadCampaign(file)
+	if err != nil {
+		return campaign, fmt.Errorf("campaign %s: %w", name, err)
+	}
+
+	return campaign, nil
+}
+
+func ReadCampaign(reader io.Reader) (Campaign, error) {
+	var campaign Campaign
+
+	// JSON is a subset of YAML so both are decoded as YAML.
+	decoder := yaml.NewDecoder(reader)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&campaign); err != nil && !errors.Is(err, io.EOF) {
+		return campaign, err
+	}
+
+	return campaign, nil
+}
+
+// Checks that the campaign describes the package, input type, and targets.
+func (campaign Campaign) Validate() error {
+	var errs []error
+	if campaign.Package == "" {
+		errs = append(errs, errors.New("the package is required"))
+	}
+	if campaign.Input == "" {
+		errs = append(errs, errors.New("the input type is required"))
+	}
+	if len(campaign.Functions) == 0 {
+		errs = append(errs, errors.New("at least one function is required"))
+	}
+	if len(campaign.Searchers) == 0 {
+		errs = append(errs, errors.New("at least one searcher is required"))
+	}
+	if _, err := campaign.targets(); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := campaign.masks(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+func (campaign Campaign) targets() ([]TargetsOption, error) {
+	functions := make([]InstructionsOption, len(campaign.Functions))
+	for i, function := range campaign.Functions {
+		functions[i] = FunctionInPackage(campaign.Package, function)
+	}
+
+	targets := make([]TargetsOption, len(campaign.Searchers))
+	for i, searcher := range campaign.Searchers {
+		var err error
+		if targets[i], err = SearchTargets(searcher, functions...); err != nil {
+			return nil, err
+		}
+	}
+
+	return targets, nil
+}
+
+func (campaign Campaign) masks() ([]fi.MaskStrategy, error) {
+	strategies := make([]fi.MaskStrategy, len(campaign.Masks))
+	for i, str := range campaign.Masks {
+		var err error
+		if strategies[i], err = fi.ParseMaskStrategy(str); err != nil {
+			return nil, err
+		}
+	}
+	return strategies, nil
+}
+
+// Checks that the functions of the campaign are in the dump of its binary.
+func (campaign Campaign) CheckFunctions(dump *obj.Dump) error {
+	var errs []error
+	for _, function := range campaign.Functions {
+		if len(dump.FunctionInPackage(campaign.Package, function)) == 0 {
+			errs = append(errs, fmt.Errorf("no function %s in %s", function, campaign.Package))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// The quantifier configuration of the campaign followed by the options, e.g., to override the directory.
+func (campaign Campaign) Configuration(options ...QuantifierOption) (QuantifierConfiguration, error) {
+	if err := campaign.Validate(); err != nil {
+		return QuantifierConfiguration{}, err
+	}
+
+	targets, _ := campaign.targets()
+	planner := []fi.PlannerOption{}
+	if campaign.Order > 0 {
+		planner = append(planner, fi.WithOrder(campaign.Order))
+	}
+	if masks, _ := campaign.masks(); len(masks) > 0 {
+		planner = append(planner, fi.WithMasks(fi.JoinMasks(masks...)))
+	}
+
+	timeout := campaign.Timeout
+	if timeout == 0 {
+		timeout = time.Minute
+	}
+
+	defaults := []QuantifierOption{
+		WithTargetOptions(targets...),
+		WithPlannerOptions(planner...),
+		WithTimeout(timeout),
+	}
+	if campaign.Directory != "" {
+		defaults = append(defaults, WithDirectory(campaign.Directory))
+	}
+	if campaign.Store != "" {
+		defaults = append(defaults, WithStore(campaign.Store))
+	}
+
+	return NewQuantifierConfiguration(append(defaults, options...)...), nil
+}
+
+// The parallel quantifier configuration of the campaign with its pool and batch size.
+func (campaign Campaign) Parallel(options ...QuantifierOption) (ParallelQuantifierConfiguration, error) {
+	configuration, err := campaign.Configuration(options...)
+	if err != nil {
+		return ParallelQuantifierConfiguration{}, err
+	}
+
+	var parallel []ParallelQuantifierOption
+	if campaign.Pool > 0 {
+		parallel = append(parallel, WithPool(campaign.Pool))
+	}
+	if campaign.Batch > 0 {
+		parallel = append(parallel, WithBatch(campaign.Batch))
+	}
+
+	return configuration.Parallel(parallel...), nil
+}
+
+// The inputs of the campaign encoded as JSON.
+func (campaign Campaign) EncodedInputs() ([]json.RawMessage, error) {
+	inputs := make([]json.RawMessage, len(campaign.Inputs))
+	for i, input := range campaign.Inputs {
+		var err error
+		if inputs[i], err = json.Marshal(input); err != nil {
+			return nil, err
+		}
+	}
+	return inputs, nil
+}
+
+// The runner of the package and input type of the campaign with its located emulator.
+func CampaignRunner[In, Out any](campaign Campaign) (*Runner[In, Out], error) {
+	qemu, err := LocateQEMU(campaign.QEMU)
+	if err != nil {
+		return nil, err
+	}
+
+	name := campaign.Name
+	if name == "" {
+		name = path.Base(campaign.Package)
+	}
+
+	environment := campaign.Environment
+	if len(environment) == 0 {
+		environment = []string{"GOARCH=arm", "GOOS=linux"}
+	}
+
+	return NewQEMURunner[In, Out](qemu, campaign.Package, name, campaign.Input, environment...), nil
+}
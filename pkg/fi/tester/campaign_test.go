@@ -0,0 +1,265 @@
+package tester
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/bits"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperproperties/gorrupt/examples/fissc/VerifyPIN_0/pkg"
+	"github.com/hyperproperties/gorrupt/pkg/fi"
+	"github.com/hyperproperties/gorrupt/pkg/iterx"
+	"github.com/hyperproperties/gorrupt/pkg/obj"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadCampaign(t *testing.T) {
+	expected := Campaign{
+		Package:   "github.com/hyperproperties/gorrupt/examples/fissc/VerifyPIN_0/pkg",
+		Input:     "VerifyPINInput",
+		Inputs:    []any{map[string]any{"UserPIN": []any{9, 9, 9, 9}}},
+		QEMU:      "qemu-arm",
+		Functions: []string{"VerifyPIN", "PINCompare"},
+		Searchers: []string{"bfr", "is"},
+		Masks:     []string{"single-bit", "hamming 2"},
+		Timeout:   90 * time.Second,
+		Pool:      512,
+		Batch:     4096,
+		Allow:     []Outcome{GoldenOutcome, DetectedOutcome},
+		Reports:   []CampaignReport{{Format: "sarif", Path: "findings.sarif"}},
+	}
+
+	tests := []struct {
+		description string
+		file        string
+		campaign    Campaign
+		err         string
+	}{
+		{
+			description: "yaml",
+			file: `
+package: github.com/hyperproperties/gorrupt/examples/fissc/VerifyPIN_0/pkg
+input: VerifyPINInput
+inputs:
+  - UserPIN: [9, 9, 9, 9]
+qemu: qemu-arm
+functions: [VerifyPIN, PINCompare]
+searchers: [bfr, is]
+masks: [single-bit, hamming 2]
+timeout: 1m30s
+pool: 512
+batch: 4096
+allow: [golden, detected]
+reports:
+  - format: sarif
+    path: findings.sarif
+`,
+			campaign: expected,
+		},
+		{
+			description: "json",
+			file: `{
+	"package": "github.com/hyperproperties/gorrupt/examples/fissc/VerifyPIN_0/pkg",
+	"input": "VerifyPINInput",
+	"inputs": [{"UserPIN": [9, 9, 9, 9]}],
+	"qemu": "qemu-arm",
+	"functions": ["VerifyPIN", "PINCompare"],
+	"searchers": ["bfr", "is"],
+	"masks": ["single-bit", "hamming 2"],
+	"timeout": "1m30s",
+	"pool": 512,
+	"batch": 4096,
+	"allow": ["golden", "detected"],
+	"reports": [{"format": "sarif", "path": "findings.sarif"}]
+}`,
+			campaign: expected,
+		},
+		{
+			description: "empty",
+			file:        "",
+			campaign:    Campaign{},
+		},
+		{
+			description: "unknown field",
+			file:        "searcher: [bfr]",
+			err:         "field searcher not found",
+		},
+		{
+			description: "unknown outcome",
+			file:        "allow: [fine]",
+			err:         "fine",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			campaign, err := ReadCampaign(strings.NewReader(tt.file))
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.campaign, campaign)
+		})
+	}
+}
+
+func TestSearchTargetsFunctions(t *testing.T) {
+	dump, err := obj.LoadFile("../../../examples/fissc/binaries/VerifyPIN_0_1/2-binary")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const path = "github.com/hyperproperties/gorrupt/examples/fissc/VerifyPIN_0/pkg"
+	verifyPIN, pinCompare := FunctionInPackage(path, "VerifyPIN"), FunctionInPackage(path, "PINCompare")
+
+	for _, name := range Searchers {
+		t.Run(name, func(t *testing.T) {
+			both, err := SearchTargets(name, verifyPIN, pinCompare)
+			assert.NoError(t, err)
+			first, err := SearchTargets(name, verifyPIN)
+			assert.NoError(t, err)
+			second, err := SearchTargets(name, pinCompare)
+			assert.NoError(t, err)
+
+			// The targets of the functions are the same when searched together or on their own.
+			expected := append(first(&dump), second(&dump)...)
+			assert.NotEmpty(t, expected)
+			assert.ElementsMatch(t, expected, both(&dump))
+		})
+	}
+}
+
+func TestCampaignValidate(t *testing.T) {
+	valid := Campaign{
+		Package:   "github.com/hyperproperties/gorrupt/examples/fissc/VerifyPIN_0/pkg",
+		Input:     "VerifyPINInput",
+		Functions: []string{"VerifyPIN"},
+		Searchers: []string{"ic"},
+	}
+
+	tests := []struct {
+		description string
+		modify      func(campaign *Campaign)
+		err         error
+		contains    string
+	}{
+		{
+			description: "valid",
+			modify:      func(*Campaign) {},
+		},
+		{
+			description: "no package",
+			modify:      func(campaign *Campaign) { campaign.Package = "" },
+			contains:    "the package is required",
+		},
+		{
+			description: "no functions and searchers",
+			modify: func(campaign *Campaign) {
+				campaign.Functions = nil
+				campaign.Searchers = nil
+			},
+			contains: "at least one searcher is required",
+		},
+		{
+			description: "unknown searcher",
+			modify:      func(campaign *Campaign) { campaign.Searchers = []string{"glitch"} },
+			err:         ErrUnknownSearcher,
+		},
+		{
+			description: "unknown mask strategy",
+			modify:      func(campaign *Campaign) { campaign.Masks = []string{"nibble"} },
+			err:         fi.ErrUnknownMaskStrategy,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			campaign := valid
+			tt.modify(&campaign)
+			err := campaign.Validate()
+			switch {
+			case tt.err != nil:
+				assert.ErrorIs(t, err, tt.err)
+			case tt.contains != "":
+				assert.ErrorContains(t, err, tt.contains)
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestExampleCampaigns(t *testing.T) {
+	for i := range 8 {
+		t.Run(fmt.Sprintf("VerifyPIN_%d", i), func(t *testing.T) {
+			campaign, err := LoadCampaign(filepath.Join("..", "..", "..", "examples", "fissc", fmt.Sprintf("VerifyPIN_%d", i), "campaign.yaml"))
+			assert.NoError(t, err)
+			assert.NoError(t, campaign.Validate())
+			assert.Equal(t, time.Minute, campaign.Timeout)
+		})
+	}
+}
+
+func TestCampaignConfiguration(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	qemu, err := filepath.Abs("testdata/qemu.sh")
+	assert.NoError(t, err)
+
+	campaign := Campaign{
+		Package:   "github.com/hyperproperties/gorrupt/examples/fissc/VerifyPIN_0/pkg",
+		Input:     "VerifyPINInput",
+		Inputs:    []any{wrongPIN},
+		QEMU:      qemu,
+		Functions: []string{"VerifyPIN"},
+		Searchers: []string{"bfr"},
+		Masks:     []string{"single-bit"},
+		Directory: t.TempDir(),
+		Pool:      2,
+		Batch:     8,
+	}
+	runner, err := CampaignRunner[pkg.VerifyPINInput, pkg.VerifyPINOutput](campaign)
+	assert.NoError(t, err)
+
+	inputs, err := campaign.EncodedInputs()
+	assert.NoError(t, err)
+	assert.Equal(t, []json.RawMessage{json.RawMessage(`{"UserPIN":[9,9,9,9]}`)}, inputs)
+
+	configuration, err := campaign.Configuration()
+	assert.NoError(t, err)
+	assert.NoError(t, runner.Prepare(ctx, wrongPIN, &configuration))
+	assert.NoError(t, campaign.CheckFunctions(configuration.Dump()))
+	assert.Error(t, Campaign{Package: campaign.Package, Functions: []string{"VerifyPUK"}}.CheckFunctions(configuration.Dump()))
+
+	// The mask strategies of the campaign only flip single bits.
+	var targets []fi.Target
+	for _, option := range configuration.Targets() {
+		targets = append(targets, option(configuration.Dump())...)
+	}
+	planner := fi.NewAttackPlanner(configuration.PlannerOptions()...)
+	plans := planner.Plan(targets...)
+	assert.NotEmpty(t, plans)
+	for _, plan := range plans {
+		for _, attack := range plan {
+			assert.Equal(t, 1, bits.OnesCount32(attack.(fi.BFR).Mask()))
+		}
+	}
+
+	// The fake emulator authenticates the user for the flips of bit 2.
+	parallel, err := campaign.Parallel()
+	assert.NoError(t, err)
+	result, err := runner.ForallParallel(ctx, parallel, iterx.Once2(wrongPIN), func(execution pinExecution) (bool, error) {
+		return !execution.Output.Ret0, nil
+	})
+	assert.NoError(t, err)
+	assert.False(t, result.Holds)
+	counterexample, ok := result.Counterexample()
+	assert.True(t, ok)
+	assert.Equal(t, uint32(4), counterexample.Plan[0].(fi.BFR).Mask())
+}
@@ -0,0 +1,114 @@
+package tester
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"github.com/hyperproperties/gorrupt/pkg/fi"
+)
+
+var (
+	_ Injector = (*QEMU)(nil)
+	_ Injector = (*FakeInjector[any])(nil)
+)
+
+// The backend executing the binary under attack plans, e.g., the qemu with fault injection.
+type Injector interface {
+	// Checks that the fault models are supported before any plan is executed.
+	Supports(ctx context.Context, models ...string) error
+	// Prepares the built binary for its executions.
+	Prepare(ctx context.Context, binary string) error
+	// Executes the binary under the plan and returns the encoded output of the generated main.
+	// The directory is for temporary files, e.g., attack files. If the binary does not finish
+	// normally then the error is an *ExecutionError with the outcome of the execution.
+	Execute(ctx context.Context, directory, binary string, plan fi.AttackPlan) ([]byte, error)
+}
+
+// Decodes the output encoded by the generated main.
+func decode[Out any](output []byte) (Out, error) {
+	var result Out
+
+	bytes, err := base64.StdEncoding.DecodeString(string(output))
+	if err != nil {
+		return result, NewExecutionError(UnparsableOutcome, output, err)
+	}
+
+	if err := json.Unmarshal(bytes, &result); err != nil {
+		return result, NewExecutionError(UnparsableOutcome, output, err)
+	}
+
+	return result, nil
+}
+
+// An in-process injector which executes no binary and requires no emulator. The output of a plan
+// is computed by a function, e.g., to unit-test properties, planners, and reports. An error of the
+// function is the outcome of the execution if it is an *ExecutionError.
+type FakeInjector[Out any] struct {
+	// The supported fault models where none supports every fault model.
+	models  []string
+	execute func(plan fi.AttackPlan) (Out, error)
+	// The number of executions.
+	executions atomic.Int64
+}
+
+func NewFakeInjector[Out any](execute func(plan fi.AttackPlan) (Out, error), models ...string) *FakeInjector[Out] {
+	return &FakeInjector[Out]{
+		models:  models,
+		execute: execute,
+	}
+}
+
+func (injector *FakeInjector[Out]) Supports(ctx context.Context, models ...string) error {
+	if len(injector.models) == 0 {
+		return nil
+	}
+
+	for _, model := range models {
+		if !slices.Contains(injector.models, model) {
+			return fmt.Errorf("%w %s: the fake supports %s", ErrUnsupportedModel, model, strings.Join(injector.models, ", "))
+		}
+	}
+
+	return nil
+}
+
+func (injector *FakeInjector[Out]) Prepare(ctx context.Context, binary string) error {
+	_, err := os.Stat(binary)
+	return err
+}
+
+func (injector *FakeInjector[Out]) Execute(ctx context.Context, directory, binary string, plan fi.AttackPlan) ([]byte, error) {
+	injector.executions.Add(1)
+
+	// Like an emulator the execution times out but is aborted by cancellation.
+	switch err := ctx.Err(); {
+	case errors.Is(err, context.DeadlineExceeded):
+		return nil, NewExecutionError(TimeoutOutcome, nil, err)
+	case err != nil:
+		return nil, err
+	}
+
+	output, err := injector.execute(plan)
+	if err != nil {
+		return nil, err
+	}
+
+	bytes, err := json.Marshal(output)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(base64.StdEncoding.EncodeToString(bytes)), nil
+}
+
+// The number of executions of the injector.
+func (injector *FakeInjector[Out]) Executions() int {
+	return int(injector.executions.Load())
+}
@@ -0,0 +1,152 @@
+package tester
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hyperproperties/gorrupt/examples/fissc/VerifyPIN_0/pkg"
+	"github.com/hyperproperties/gorrupt/pkg/fi"
+	"github.com/hyperproperties/gorrupt/pkg/iterx"
+	"github.com/hyperproperties/gorrupt/pkg/obj"
+	"github.com/stretchr/testify/assert"
+)
+
+// An in-process VerifyPIN with a wrong PIN like testdata/qemu.sh.
+func fakeVerifyPIN(plan fi.AttackPlan) (pkg.VerifyPINOutput, error) {
+	switch plan.String() {
+	case "[is 4096 0]":
+		return pkg.VerifyPINOutput{Countermeasure: true, PTC: 2}, nil
+	case "[is 4100 0]":
+		return pkg.VerifyPINOutput{Ret0: true, PTC: 3}, nil
+	case "[is 4104 0]":
+		return pkg.VerifyPINOutput{}, NewExecutionError(CrashOutcome, nil, errors.New("nil pointer dereference"))
+	}
+	return pkg.VerifyPINOutput{PTC: 2}, nil
+}
+
+func TestFakeInjector(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	injector := NewFakeInjector(fakeVerifyPIN, "is")
+	runner := NewInjectorRunner[pkg.VerifyPINInput, pkg.VerifyPINOutput](
+		injector, "github.com/hyperproperties/gorrupt/examples/fissc/VerifyPIN_0/pkg", "pkg", "",
+		"GOARCH=arm", "GOOS=linux",
+	)
+	directory := t.TempDir()
+	configuration := func(options ...QuantifierOption) QuantifierConfiguration {
+		return NewQuantifierConfiguration(append([]QuantifierOption{
+			WithDirectory(directory), WithTimeout(time.Minute), WithLimit(-1),
+		}, options...)...)
+	}
+	maskedOrDetected := func(execution pinExecution) (bool, error) {
+		return execution.Outcome == GoldenOutcome || execution.Outcome == DetectedOutcome, nil
+	}
+
+	result, err := runner.Forall(ctx, configuration(WithTargetOptions(skips(0x1000, 0x1004, 0x1008, 0x100c))),
+		iterx.Once2(wrongPIN), maskedOrDetected)
+	assert.NoError(t, err)
+	assert.False(t, result.Holds)
+	assert.Len(t, result.Executions, 2)
+	assert.Equal(t, "[is 4100 0]", result.Executions[0].Plan.String())
+	assert.Equal(t, CorruptionOutcome, result.Executions[0].Outcome)
+	assert.Equal(t, "[is 4104 0]", result.Executions[1].Plan.String())
+	assert.Equal(t, CrashOutcome, result.Executions[1].Outcome)
+	// The golden execution and one per plan.
+	assert.Equal(t, 5, injector.Executions())
+
+	// The parallel quantifier finds the same counterexamples.
+	result, err = runner.ForallParallel(ctx,
+		configuration(WithTargetOptions(skips(0x1000, 0x1004, 0x1008, 0x100c))).Parallel(WithPool(2), WithBatch(8)),
+		iterx.Once2(wrongPIN), maskedOrDetected)
+	assert.NoError(t, err)
+	assert.Len(t, result.Executions, 2)
+	assert.Equal(t, 10, injector.Executions())
+
+	// Without targets both quantifiers reuse the golden execution for the empty plan.
+	result, err = runner.Forall(ctx, configuration(), iterx.Once2(wrongPIN), maskedOrDetected)
+	assert.NoError(t, err)
+	assert.True(t, result.Holds)
+	assert.Equal(t, 11, injector.Executions())
+	result, err = runner.ForallParallel(ctx, configuration().Parallel(), iterx.Once2(wrongPIN),
+		func(execution pinExecution) (bool, error) {
+			return false, nil
+		})
+	assert.NoError(t, err)
+	assert.Len(t, result.Executions, 1)
+	assert.Empty(t, result.Executions[0].Plan)
+	assert.Equal(t, GoldenOutcome, result.Executions[0].Outcome)
+	assert.Equal(t, 12, injector.Executions())
+
+	// The unsupported fault models fail before any plan is executed.
+	_, err = runner.Forall(ctx, configuration(WithTargetOptions(func(*obj.Dump) []fi.Target {
+		return []fi.Target{fi.NewBFRTarget(fi.NewTransition(0x1000, 0x1004), 0)}
+	})), iterx.Once2(wrongPIN), maskedOrDetected)
+	assert.ErrorIs(t, err, ErrUnsupportedModel)
+	assert.Equal(t, 12, injector.Executions())
+}
+
+func TestFakeInjectorExecute(t *testing.T) {
+	injector := NewFakeInjector(fakeVerifyPIN)
+	runner := NewInjectorRunner[pkg.VerifyPINInput, pkg.VerifyPINOutput](injector, "", "", "")
+	assert.NoError(t, injector.Supports(context.Background(), "bfr", "is"))
+
+	tests := []struct {
+		description string
+		ctx         func() (context.Context, context.CancelFunc)
+		plan        fi.AttackPlan
+		output      pkg.VerifyPINOutput
+		outcome     Outcome
+		err         error
+	}{
+		{
+			description: "output",
+			ctx:         func() (context.Context, context.CancelFunc) { return context.WithCancel(context.Background()) },
+			plan:        fi.AttackPlan{fi.NewIS(0x1004, 0)},
+			output:      pkg.VerifyPINOutput{Ret0: true, PTC: 3},
+		},
+		{
+			description: "crash",
+			ctx:         func() (context.Context, context.CancelFunc) { return context.WithCancel(context.Background()) },
+			plan:        fi.AttackPlan{fi.NewIS(0x1008, 0)},
+			outcome:     CrashOutcome,
+		},
+		{
+			description: "timeout",
+			ctx:         func() (context.Context, context.CancelFunc) { return context.WithTimeout(context.Background(), 0) },
+			outcome:     TimeoutOutcome,
+		},
+		{
+			description: "cancelled",
+			ctx: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx, cancel
+			},
+			err: context.Canceled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			ctx, cancel := tt.ctx()
+			defer cancel()
+
+			output, err := runner.Execute(ctx, t.TempDir(), "binary", tt.plan)
+			var failed *ExecutionError
+			switch {
+			case tt.err != nil:
+				assert.ErrorIs(t, err, tt.err)
+				assert.False(t, errors.As(err, &failed))
+			case tt.outcome != GoldenOutcome:
+				assert.ErrorAs(t, err, &failed)
+				assert.Equal(t, tt.outcome, failed.Outcome)
+			default:
+				assert.NoError(t, err)
+				assert.Equal(t, tt.output, output)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package tester
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"reflect"
+	"regexp"
+
+	"github.com/hyperproperties/gorrupt/pkg/fi"
+)
+
+// Outcome is the classification of the execution of a binary under an attack plan.
+type Outcome byte
+
+const (
+	// The output is equal to the output without any attack, i.e., the fault was masked.
+	GoldenOutcome = Outcome(iota)
+	// The output differs from the golden output without being detected (silent data corruption).
+	CorruptionOutcome
+	// The output reports that a countermeasure detected the fault.
+	DetectedOutcome
+	// The binary crashed, e.g., by a panic or a signal raised in the emulated target.
+	CrashOutcome
+	// The execution did not finish before its timeout.
+	TimeoutOutcome
+	// The emulator failed, e.g., it could not be started or rejected the attack file.
+	EmulatorOutcome
+	// The binary finished but its output could not be decoded.
+	UnparsableOutcome
+)
+
+var outcomes = [...]string{"golden", "corruption", "detected", "crash", "timeout", "emulator", "unparsable"}
+
+func (outcome Outcome) String() string {
+	if int(outcome) < len(outcomes) {
+		return outcomes[outcome]
+	}
+	return fmt.Sprintf("Outcome(%d)", outcome)
+}
+
+// Checks if the execution finished with a decoded output.
+func (outcome Outcome) HasOutput() bool {
+	return outcome <= DetectedOutcome
+}
+
+// Outputs implementing Detector report whether a countermeasure detected a fault.
+type Detector interface {
+	Detected() bool
+}
+
+// The error of an execution which did not finish with a decoded output.
+type ExecutionError struct {
+	Outcome Outcome
+	// The combined output of the emulator and binary.
+	Output []byte
+	Err    error
+}
+
+func NewExecutionError(outcome Outcome, output []byte, err error) *ExecutionError {
+	return &ExecutionError{
+		Outcome: outcome,
+		Output:  output,
+		Err:     err,
+	}
+}
+
+func (err *ExecutionError) Error() string {
+	return fmt.Sprintf("%s: %v", err.Outcome, err.Err)
+}
+
+func (err *ExecutionError) Unwrap() error {
+	return err.Err
+}
+
+// The execution of the binary for an input under an attack plan.
+type Execution[In, Out any] struct {
+	Input In
+	Plan  fi.AttackPlan
+	// The output without any attack.
+	Golden Out
+	// The output under the attack plan. It is the zero value if the outcome has no output.
+	Output  Out
+	Outcome Outcome
+	// The error of an execution without output, e.g., a crash or timeout.
+	Err error
+}
+
+// Classifies the output of a finished execution by comparing it to the golden output.
+func Classify[Out any](golden, output Out) Outcome {
+	if detector, ok := any(output).(Detector); ok && detector.Detected() {
+		return DetectedOutcome
+	}
+
+	if reflect.DeepEqual(golden, output) {
+		return GoldenOutcome
+	}
+
+	return CorruptionOutcome
+}
+
+var (
+	// E.g., "qemu: uncaught target signal 11 (Segmentation fault) - core dumped".
+	targetSignal = regexp.MustCompile(`(?m)^qemu: uncaught target signal`)
+	// E.g., "qemu-arm: Could not open 'binary': No such file or directory".
+	emulatorMessage = regexp.MustCompile(`(?m)^qemu-[\w-]+: `)
+)
+
+// Classifies the failure of the command running the emulator from its error and output.
+func failure(err error, output []byte) Outcome {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return TimeoutOutcome
+	}
+
+	var exit *exec.ExitError
+	if !errors.As(err, &exit) {
+		return EmulatorOutcome
+	}
+
+	switch {
+	case targetSignal.Match(output):
+		return CrashOutcome
+	case emulatorMessage.Match(output):
+		return EmulatorOutcome
+	case exit.ExitCode() == 126, exit.ExitCode() == 127:
+		// The emulator could not be executed, e.g., by the interpreter of a script.
+		return EmulatorOutcome
+	}
+
+	return CrashOutcome
+}
+
+func (outcome Outcome) MarshalText() ([]byte, error) {
+	return []byte(outcome.String()), nil
+}
+
+func (outcome *Outcome) UnmarshalText(text []byte) error {
+	for idx, name := range outcomes {
+		if name == string(text) {
+			*outcome = Outcome(idx)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown outcome %q", text)
+}
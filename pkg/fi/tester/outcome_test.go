@@ -0,0 +1,81 @@
+package tester
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type output struct {
+	Value          int
+	Countermeasure bool
+}
+
+func (output output) Detected() bool {
+	return output.Countermeasure
+}
+
+func TestClassify(t *testing.T) {
+	golden := output{Value: 1}
+	assert.Equal(t, GoldenOutcome, Classify(golden, output{Value: 1}))
+	assert.Equal(t, CorruptionOutcome, Classify(golden, output{Value: 2}))
+	assert.Equal(t, DetectedOutcome, Classify(golden, output{Value: 2, Countermeasure: true}))
+	assert.Equal(t, CorruptionOutcome, Classify(1, 2))
+}
+
+func TestFailure(t *testing.T) {
+	exit := func(code string) error {
+		return exec.Command("sh", "-c", "exit "+code).Run()
+	}
+
+	tests := []struct {
+		description string
+		err         error
+		output      string
+		outcome     Outcome
+	}{
+		{
+			description: "deadline exceeded",
+			err:         errors.Join(context.DeadlineExceeded, errors.New("killed")),
+			outcome:     TimeoutOutcome,
+		},
+		{
+			description: "not started",
+			err:         exec.ErrNotFound,
+			outcome:     EmulatorOutcome,
+		},
+		{
+			description: "emulator not found",
+			err:         exit("127"),
+			output:      "sh: 1: qemu-arm: not found",
+			outcome:     EmulatorOutcome,
+		},
+		{
+			description: "emulator message",
+			err:         exit("1"),
+			output:      "qemu-arm: Could not open 'binary': No such file or directory",
+			outcome:     EmulatorOutcome,
+		},
+		{
+			description: "target signal",
+			err:         exit("139"),
+			output:      "qemu: uncaught target signal 11 (Segmentation fault) - core dumped",
+			outcome:     CrashOutcome,
+		},
+		{
+			description: "panic",
+			err:         exit("2"),
+			output:      "panic: runtime error: index out of range [4] with length 4",
+			outcome:     CrashOutcome,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			assert.Equal(t, tt.outcome, failure(tt.err, []byte(tt.output)))
+		})
+	}
+}
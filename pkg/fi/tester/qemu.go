@@ -0,0 +1,246 @@
+package tester
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/hyperproperties/gorrupt/pkg/execx"
+	"github.com/hyperproperties/gorrupt/pkg/fi"
+)
+
+// Environment variable used for locating the emulator when no path is given.
+const QEMUEnvironment = "GORRUPT_QEMU"
+
+// The name of the emulator which is searched for in the PATH.
+const QEMUName = "qemu-arm"
+
+var (
+	// Returned if the emulator is not found or does not answer the version handshake.
+	ErrNoQEMU = errors.New("no qemu found")
+	// Returned if the emulator does not support fault injection through -fi.
+	ErrNoFaultInjection = errors.New("qemu does not support fault injection")
+	// Returned if the emulator does not support a fault model of the targets or plans.
+	ErrUnsupportedModel = errors.New("qemu does not support the fault model")
+)
+
+var (
+	// E.g., "qemu-arm version 8.2.0 (qemu-fi)".
+	qemuVersion = regexp.MustCompile(`version (\S+)`)
+	// A fault model listed by "-fi help", e.g., "fi-model: bfr". Other lines are ignored.
+	qemuModel = regexp.MustCompile(`(?m)^fi-model: ([a-z-]+)\s*$`)
+	// The answer of a qemu without fault injection, e.g., "qemu: unknown option 'fi'".
+	qemuUnknownFI = regexp.MustCompile(`(?m)^qemu[\w-]*: unknown option 'fi'`)
+)
+
+// The answer of the emulator to the handshake.
+type Handshake struct {
+	// The version of the emulator, e.g., "8.2.0".
+	Version string
+	// The fault models listed by "-fi help", e.g., "bfr" and "is". It is nil if the emulator
+	// does not answer the query in which case the fault models are not checked.
+	Models []string
+}
+
+// The emulator with fault injection. Its handshake is made once on first use.
+type QEMU struct {
+	path string
+
+	mutex     sync.Mutex
+	handshake *Handshake
+	err       error
+}
+
+// Creates the emulator at the path without locating or checking it.
+func NewQEMU(path string) *QEMU {
+	return &QEMU{
+		path: path,
+	}
+}
+
+// Locates the emulator at the path, or if empty, from the environment variable or else in the PATH.
+func LocateQEMU(path string) (*QEMU, error) {
+	from := "the path"
+	if path == "" {
+		from, path = "$"+QEMUEnvironment, os.Getenv(QEMUEnvironment)
+	}
+	if path == "" {
+		from, path = "the PATH", QEMUName
+	}
+
+	located, err := exec.LookPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w at %s from %s: %w", ErrNoQEMU, path, from, err)
+	}
+
+	return NewQEMU(located), nil
+}
+
+func (qemu *QEMU) Path() string {
+	return qemu.path
+}
+
+// Queries the version of the emulator by -version and its fault models by "-fi help".
+// A successful handshake (or one failing for other reasons than the context) is reused.
+func (qemu *QEMU) Handshake(ctx context.Context) (Handshake, error) {
+	qemu.mutex.Lock()
+	defer qemu.mutex.Unlock()
+
+	if qemu.handshake != nil {
+		return *qemu.handshake, qemu.err
+	}
+
+	handshake, err := qemu.shake(ctx)
+	if ctx.Err() != nil {
+		return handshake, err
+	}
+	qemu.handshake, qemu.err = &handshake, err
+
+	return handshake, err
+}
+
+func (qemu *QEMU) shake(ctx context.Context) (Handshake, error) {
+	var handshake Handshake
+
+	output, err := exec.CommandContext(ctx, qemu.path, "-version").CombinedOutput()
+	if err != nil {
+		return handshake, fmt.Errorf("%w at %s: %w: %s", ErrNoQEMU, qemu.path, err, strings.TrimSpace(string(output)))
+	}
+	match := qemuVersion.FindSubmatch(output)
+	if match == nil {
+		return handshake, fmt.Errorf("%w at %s: no version in %q", ErrNoQEMU, qemu.path, strings.TrimSpace(string(output)))
+	}
+	handshake.Version = string(match[1])
+
+	// The query is optional and an emulator not implementing it fails or prints its usage.
+	output, _ = exec.CommandContext(ctx, qemu.path, "-fi", "help").CombinedOutput()
+	if qemuUnknownFI.Match(output) {
+		return handshake, fmt.Errorf("%w: %s version %s: %s",
+			ErrNoFaultInjection, qemu.path, handshake.Version, strings.TrimSpace(string(output)))
+	}
+	handshake.Models = parseModels(output)
+
+	return handshake, nil
+}
+
+// The fault models of the lines "fi-model: NAME" of the output of "-fi help" (if any).
+func parseModels(output []byte) (models []string) {
+	for _, match := range qemuModel.FindAllSubmatch(output, -1) {
+		models = append(models, string(match[1]))
+	}
+	return
+}
+
+// Checks that the emulator supports fault injection and the fault models.
+// The fault models are only checked if the emulator lists them.
+func (qemu *QEMU) Supports(ctx context.Context, models ...string) error {
+	handshake, err := qemu.Handshake(ctx)
+	if err != nil || handshake.Models == nil {
+		return err
+	}
+
+	var errs []error
+	for _, model := range models {
+		if !slices.Contains(handshake.Models, model) {
+			errs = append(errs, fmt.Errorf("%w %s: %s version %s supports %s",
+				ErrUnsupportedModel, model, qemu.path, handshake.Version, strings.Join(handshake.Models, ", ")))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (qemu *QEMU) Prepare(ctx context.Context, binary string) error {
+	_, err := os.Stat(binary)
+	return err
+}
+
+// Writes the plan to an attack file in the directory and runs the binary under it.
+func (qemu *QEMU) Execute(ctx context.Context, directory, binary string, plan fi.AttackPlan) ([]byte, error) {
+	file, err := os.CreateTemp(directory, "*-fi")
+	if err != nil {
+		return nil, err
+	}
+	attack := file.Name()
+
+	for _, attack := range plan {
+		io.WriteString(file, attack.String()+"\n")
+	}
+	if err := file.Close(); err != nil {
+		return nil, errors.Join(err, os.Remove(attack))
+	}
+
+	output, err := qemu.Run(ctx, attack, binary)
+	if rmErr := os.Remove(attack); rmErr != nil {
+		return output, errors.Join(rmErr, err)
+	}
+
+	return output, err
+}
+
+// Runs the binary under the attack file and returns the combined output of the emulator and binary.
+// If the binary does not finish normally then the error is an *ExecutionError.
+func (qemu *QEMU) Run(ctx context.Context, attack, binary string) ([]byte, error) {
+	var output []byte
+	err := execx.RunCommandContext(ctx, func(command *exec.Cmd) (err error) {
+		output, err = command.CombinedOutput()
+		return err
+	}, qemu.path, "-fi", attack, binary, "-no-shutdown", "-no-reboot")
+
+	if errors.Is(err, context.Canceled) {
+		return output, err
+	}
+
+	if err != nil {
+		return output, NewExecutionError(failure(err, output), output, err)
+	}
+
+	return output, nil
+}
+
+var _ fi.TagetVisitor = (*modeler)(nil)
+
+// Collects the distinct fault models of targets.
+type modeler struct {
+	models []string
+}
+
+func (modeler *modeler) add(model string) {
+	if !slices.Contains(modeler.models, model) {
+		modeler.models = append(modeler.models, model)
+	}
+}
+
+func (modeler *modeler) BFR(fi.BFRTarget) { modeler.add("bfr") }
+func (modeler *modeler) BFM(fi.BFMTarget) { modeler.add("bfm") }
+func (modeler *modeler) SAR(fi.SARTarget) { modeler.add("sar") }
+func (modeler *modeler) SR(fi.SRTarget)   { modeler.add("sr") }
+func (modeler *modeler) IS(fi.ISTarget)   { modeler.add("is") }
+func (modeler *modeler) IC(fi.ICTarget)   { modeler.add("ic") }
+
+// The distinct fault models of the targets.
+func TargetModels(targets ...fi.Target) []string {
+	var modeler modeler
+	for _, target := range targets {
+		target.Visit(&modeler)
+	}
+	return modeler.models
+}
+
+// The distinct fault models of the plan which are the first fields of its attacks.
+func PlanModels(plan fi.AttackPlan) []string {
+	var modeler modeler
+	for _, attack := range plan {
+		if fields := strings.Fields(attack.String()); len(fields) > 0 {
+			modeler.add(fields[0])
+		}
+	}
+	return modeler.models
+}
@@ -0,0 +1,210 @@
+package tester
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperproperties/gorrupt/examples/fissc/VerifyPIN_0/pkg"
+	"github.com/hyperproperties/gorrupt/pkg/fi"
+	"github.com/hyperproperties/gorrupt/pkg/iterx"
+	"github.com/hyperproperties/gorrupt/pkg/obj"
+	"github.com/stretchr/testify/assert"
+)
+
+// Writes an executable script of an emulator to the directory.
+func script(t *testing.T, directory, name, content string) string {
+	path := filepath.Join(directory, name)
+	assert.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+content), 0755))
+	return path
+}
+
+func TestLocateQEMU(t *testing.T) {
+	fake, err := filepath.Abs("testdata/qemu.sh")
+	assert.NoError(t, err)
+
+	directory := t.TempDir()
+	inPath := script(t, directory, QEMUName, "")
+
+	tests := []struct {
+		description string
+		path        string
+		environment string
+		located     string
+		err         error
+	}{
+		{
+			description: "explicit path",
+			path:        fake,
+			environment: inPath,
+			located:     fake,
+		},
+		{
+			description: "environment",
+			environment: fake,
+			located:     fake,
+		},
+		{
+			description: "PATH",
+			located:     inPath,
+		},
+		{
+			description: "missing",
+			path:        filepath.Join(directory, "qemu-riscv32"),
+			err:         ErrNoQEMU,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			t.Setenv(QEMUEnvironment, tt.environment)
+			t.Setenv("PATH", directory)
+
+			qemu, err := LocateQEMU(tt.path)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.located, qemu.Path())
+		})
+	}
+}
+
+func TestHandshake(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	fake, err := filepath.Abs("testdata/qemu.sh")
+	assert.NoError(t, err)
+
+	usage, err := filepath.Abs("testdata/qemu-usage.txt")
+	assert.NoError(t, err)
+	unknown, err := filepath.Abs("testdata/qemu-unknown-option.txt")
+	assert.NoError(t, err)
+
+	directory := t.TempDir()
+	tests := []struct {
+		description string
+		qemu        string
+		models      []string
+		handshake   Handshake
+		err         error
+	}{
+		{
+			description: "fault injection",
+			qemu:        fake,
+			models:      []string{"bfr", "is"},
+			handshake:   Handshake{Version: "8.2.0", Models: []string{"bfr", "bfm", "sar", "sr", "is", "ic"}},
+		},
+		{
+			description: "unsupported model",
+			qemu:        script(t, directory, "qemu-is", `[ "$1" = "-version" ] && echo "qemu-arm version 7.0.0" || echo "fi-model: is"`),
+			models:      []string{"is", "bfr"},
+			handshake:   Handshake{Version: "7.0.0", Models: []string{"is"}},
+			err:         ErrUnsupportedModel,
+		},
+		{
+			description: "no query of the fault models",
+			qemu: script(t, directory, "qemu-usage", `[ "$1" = "-version" ] && echo "qemu-arm version 8.2.0" && exit 0
+cat `+usage+`; exit 1`),
+			models:    []string{"bfr", "is"},
+			handshake: Handshake{Version: "8.2.0"},
+		},
+		{
+			description: "no fault injection",
+			qemu: script(t, directory, "qemu-arm", `[ "$1" = "-version" ] && echo "qemu-arm version 8.2.0" && exit 0
+cat `+unknown+`; exit 1`),
+			handshake: Handshake{Version: "8.2.0"},
+			err:       ErrNoFaultInjection,
+		},
+		{
+			description: "not an emulator",
+			qemu:        script(t, directory, "true", "exit 0"),
+			err:         ErrNoQEMU,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			qemu := NewQEMU(tt.qemu)
+			handshake, _ := qemu.Handshake(ctx)
+			assert.Equal(t, tt.handshake, handshake)
+
+			err := qemu.Supports(ctx, tt.models...)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestParseModels(t *testing.T) {
+	tests := []struct {
+		description string
+		file        string
+		models      []string
+	}{
+		{
+			// The usage of qemu-user for "-fi help" without a program mentions "model" in prose only.
+			description: "usage",
+			file:        "testdata/qemu-usage.txt",
+		},
+		{
+			description: "unknown option",
+			file:        "testdata/qemu-unknown-option.txt",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			output, err := os.ReadFile(tt.file)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.models, parseModels(output))
+		})
+	}
+
+	assert.Equal(t, []string{"bfr", "bfr-flow"}, parseModels([]byte("Fault models:\nfi-model: bfr\nfi-model: bfr-flow\nthe model of a fault\n")))
+}
+
+func TestModels(t *testing.T) {
+	assert.Equal(t, []string{"is", "bfr"}, TargetModels(
+		fi.NewISTarget(0x1000), fi.NewBFRTarget(fi.NewTransition(0x1000, 0x1004), 0), fi.NewISTarget(0x1004),
+	))
+	assert.Equal(t, []string{"bfr", "is"}, PlanModels(fi.AttackPlan{
+		fi.NewBFR(0, 0, 0x1000, 0x1004, 1), fi.NewIS(0x1000, 0), fi.NewBFR(1, 0, 0x1000, 0x1004, 1),
+	}))
+	assert.Empty(t, PlanModels(nil))
+}
+
+func TestUnsupportedModel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	// The emulator only supports skipping instructions and fails for any attack plan.
+	qemu := script(t, t.TempDir(), "qemu-is", `[ "$1" = "-version" ] && echo "qemu-arm version 8.2.0" && exit 0
+[ "$2" = "help" ] && echo "fi-model: is" && exit 0
+exit 1`)
+	runner := NewRunner[pkg.VerifyPINInput, pkg.VerifyPINOutput](
+		qemu, "github.com/hyperproperties/gorrupt/examples/fissc/VerifyPIN_0/pkg", "pkg",
+		"GOARCH=arm", "GOOS=linux",
+	)
+	configuration := NewQuantifierConfiguration(
+		WithDirectory(t.TempDir()), WithTimeout(time.Minute),
+		WithTargetOptions(func(*obj.Dump) []fi.Target {
+			return []fi.Target{fi.NewBFRTarget(fi.NewTransition(0x1000, 0x1004), 0)}
+		}),
+	)
+
+	_, err := runner.Forall(ctx, configuration, iterx.Once2(wrongPIN), func(pinExecution) (bool, error) {
+		return true, nil
+	})
+	assert.ErrorIs(t, err, ErrUnsupportedModel)
+
+	_, err = runner.Replay(ctx, configuration, wrongPIN, fi.AttackPlan{fi.NewBFR(0, 0, 0x1000, 0x1004, 1)})
+	assert.ErrorIs(t, err, ErrUnsupportedModel)
+}
@@ -0,0 +1,246 @@
+package tester
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Checks if the predicate holds for some execution of an input under an attack plan.
+// It terminates when the limit of witnesses is reached or the predicate returns an error.
+func (runner *Runner[In, Out]) Exists(
+	ctx context.Context,
+	configuration QuantifierConfiguration,
+	inputs iter.Seq2[int, In],
+	predicate func(execution Execution[In, Out]) (bool, error),
+) (Result[In, Out], error) {
+	// The witnesses are the counterexamples of the negated predicate.
+	result, err := runner.Forall(ctx, configuration, inputs, negate(predicate))
+	result.Holds = !result.Holds
+	return result, err
+}
+
+// Checks like Exists but executes the attack plans in parallel.
+// It terminates at the end of the batch in which the limit of witnesses is reached.
+func (runner *Runner[In, Out]) ExistsParallel(
+	ctx context.Context,
+	configuration ParallelQuantifierConfiguration,
+	inputs iter.Seq2[int, In],
+	predicate func(execution Execution[In, Out]) (bool, error),
+) (Result[In, Out], error) {
+	result, err := runner.ForallParallel(ctx, configuration, inputs, negate(predicate))
+	result.Holds = !result.Holds
+	return result, err
+}
+
+// Negates the predicate. Executions for which it returns an error are neither counterexamples nor witnesses.
+func negate[In, Out any](predicate func(execution Execution[In, Out]) (bool, error)) func(execution Execution[In, Out]) (bool, error) {
+	return func(execution Execution[In, Out]) (bool, error) {
+		ok, err := predicate(execution)
+		if err != nil {
+			return true, err
+		}
+		return !ok, nil
+	}
+}
+
+// The verdict of a formula and the executions bound by its quantifiers which witness it.
+// E.g., the executions satisfying an existential quantifier or violating a universal one.
+type Verdict[In, Out any] struct {
+	Holds   bool
+	Witness []Execution[In, Out]
+}
+
+func (verdict Verdict[In, Out]) String() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "holds: %v", verdict.Holds)
+	for idx, execution := range verdict.Witness {
+		fmt.Fprintf(&builder, "\ne%d: input %+v, plan %s, outcome %s, output %+v",
+			idx, execution.Input, execution.Plan, execution.Outcome, execution.Output)
+	}
+	return builder.String()
+}
+
+// A formula over the executions bound by the enclosing quantifiers, e.g., a hyperproperty.
+type Formula[In, Out any] interface {
+	Evaluate(ctx context.Context, runner *Runner[In, Out], bound []Execution[In, Out]) (Verdict[In, Out], error)
+}
+
+type FormulaFunc[In, Out any] func(ctx context.Context, runner *Runner[In, Out], bound []Execution[In, Out]) (Verdict[In, Out], error)
+
+func (formula FormulaFunc[In, Out]) Evaluate(
+	ctx context.Context, runner *Runner[In, Out], bound []Execution[In, Out],
+) (Verdict[In, Out], error) {
+	return formula(ctx, runner, bound)
+}
+
+// The inputs a quantifier ranges over given the executions bound by the enclosing quantifiers.
+type Inputs[In, Out any] func(bound []Execution[In, Out]) iter.Seq2[int, In]
+
+// Formulas constructs the formulas over the executions of the runner. E.g., "there exists
+// a fault such that a wrong PIN authenticates the user" is
+//
+//	formulas := runner.Formulas()
+//	formula := formulas.Forall(golden, formulas.Over(wrongPINs),
+//		formulas.Exists(faulted, formulas.Input(0),
+//			formulas.Predicate(func(executions ...tester.Execution[In, Out]) (bool, error) {
+//				return executions[1].Output.Authenticated, nil
+//			}),
+//		),
+//	)
+//	verdict, err := formulas.Check(ctx, formula)
+type Formulas[In, Out any] struct {
+	runner *Runner[In, Out]
+}
+
+func (runner *Runner[In, Out]) Formulas() Formulas[In, Out] {
+	return Formulas[In, Out]{runner}
+}
+
+// Evaluates the formula without any bound executions.
+func (formulas Formulas[In, Out]) Check(ctx context.Context, formula Formula[In, Out]) (Verdict[In, Out], error) {
+	return formula.Evaluate(ctx, formulas.runner, nil)
+}
+
+// Ranges over the inputs regardless of the bound executions.
+func (formulas Formulas[In, Out]) Over(inputs iter.Seq2[int, In]) Inputs[In, Out] {
+	return func([]Execution[In, Out]) iter.Seq2[int, In] {
+		return inputs
+	}
+}
+
+// Ranges over the input of the execution bound by the quantifier at the index (outermost is 0).
+func (formulas Formulas[In, Out]) Input(index int) Inputs[In, Out] {
+	return func(bound []Execution[In, Out]) iter.Seq2[int, In] {
+		return func(yield func(int, In) bool) {
+			yield(0, bound[index].Input)
+		}
+	}
+}
+
+// The predicate over the executions bound by the enclosing quantifiers in order (outermost first).
+func (formulas Formulas[In, Out]) Predicate(
+	predicate func(executions ...Execution[In, Out]) (bool, error),
+) Formula[In, Out] {
+	return FormulaFunc[In, Out](func(ctx context.Context, runner *Runner[In, Out], bound []Execution[In, Out]) (Verdict[In, Out], error) {
+		holds, err := predicate(bound...)
+		return Verdict[In, Out]{holds, bound}, err
+	})
+}
+
+func (formulas Formulas[In, Out]) Not(formula Formula[In, Out]) Formula[In, Out] {
+	return FormulaFunc[In, Out](func(ctx context.Context, runner *Runner[In, Out], bound []Execution[In, Out]) (Verdict[In, Out], error) {
+		verdict, err := formula.Evaluate(ctx, runner, bound)
+		verdict.Holds = !verdict.Holds
+		return verdict, err
+	})
+}
+
+// Holds if all the formulas hold. The evaluation terminates at the first formula which does not hold.
+func (formulas Formulas[In, Out]) And(operands ...Formula[In, Out]) Formula[In, Out] {
+	return FormulaFunc[In, Out](func(ctx context.Context, runner *Runner[In, Out], bound []Execution[In, Out]) (Verdict[In, Out], error) {
+		verdict := Verdict[In, Out]{true, bound}
+		for _, operand := range operands {
+			var err error
+			if verdict, err = operand.Evaluate(ctx, runner, bound); err != nil || !verdict.Holds {
+				return verdict, err
+			}
+		}
+		return verdict, nil
+	})
+}
+
+// Holds if any of the formulas hold. The evaluation terminates at the first formula which holds.
+func (formulas Formulas[In, Out]) Or(operands ...Formula[In, Out]) Formula[In, Out] {
+	return FormulaFunc[In, Out](func(ctx context.Context, runner *Runner[In, Out], bound []Execution[In, Out]) (Verdict[In, Out], error) {
+		verdict := Verdict[In, Out]{false, bound}
+		for _, operand := range operands {
+			var err error
+			if verdict, err = operand.Evaluate(ctx, runner, bound); err != nil || verdict.Holds {
+				return verdict, err
+			}
+		}
+		return verdict, nil
+	})
+}
+
+// Binds an execution for every input under every attack plan of the configuration and holds if the body
+// holds for all of them. The witness of a violation is the executions bound when the body was violated.
+func (formulas Formulas[In, Out]) Forall(
+	configuration QuantifierConfiguration, inputs Inputs[In, Out], body Formula[In, Out],
+) Formula[In, Out] {
+	return formulas.quantify(true, func(ctx context.Context, bound []Execution[In, Out], predicate func(Execution[In, Out]) (bool, error)) (bool, error) {
+		result, err := formulas.runner.Forall(ctx, configuration, inputs(bound), predicate)
+		return result.Holds, err
+	}, body)
+}
+
+// Like Forall but the attack plans are executed in parallel.
+func (formulas Formulas[In, Out]) ForallParallel(
+	configuration ParallelQuantifierConfiguration, inputs Inputs[In, Out], body Formula[In, Out],
+) Formula[In, Out] {
+	return formulas.quantify(true, func(ctx context.Context, bound []Execution[In, Out], predicate func(Execution[In, Out]) (bool, error)) (bool, error) {
+		result, err := formulas.runner.ForallParallel(ctx, configuration, inputs(bound), predicate)
+		return result.Holds, err
+	}, body)
+}
+
+// Binds an execution for every input under every attack plan of the configuration and holds if the body
+// holds for one of them. The witness is the executions bound when the body was satisfied.
+func (formulas Formulas[In, Out]) Exists(
+	configuration QuantifierConfiguration, inputs Inputs[In, Out], body Formula[In, Out],
+) Formula[In, Out] {
+	return formulas.quantify(false, func(ctx context.Context, bound []Execution[In, Out], predicate func(Execution[In, Out]) (bool, error)) (bool, error) {
+		result, err := formulas.runner.Exists(ctx, configuration, inputs(bound), predicate)
+		return result.Holds, err
+	}, body)
+}
+
+// Like Exists but the attack plans are executed in parallel.
+func (formulas Formulas[In, Out]) ExistsParallel(
+	configuration ParallelQuantifierConfiguration, inputs Inputs[In, Out], body Formula[In, Out],
+) Formula[In, Out] {
+	return formulas.quantify(false, func(ctx context.Context, bound []Execution[In, Out], predicate func(Execution[In, Out]) (bool, error)) (bool, error) {
+		result, err := formulas.runner.ExistsParallel(ctx, configuration, inputs(bound), predicate)
+		return result.Holds, err
+	}, body)
+}
+
+// Evaluates the body for every execution bound by the quantifier and keeps the first witness deciding its verdict.
+// The deciding witness is of a violation if the quantifier is universal and of a satisfaction otherwise.
+// If no execution decides the verdict then the witness is that of the first evaluation of the body.
+// E.g., the witness of "forall e0 exists e1" is that of "exists e1" for the first e0.
+func (formulas Formulas[In, Out]) quantify(
+	universal bool,
+	quantifier func(ctx context.Context, bound []Execution[In, Out], predicate func(Execution[In, Out]) (bool, error)) (bool, error),
+	body Formula[In, Out],
+) Formula[In, Out] {
+	return FormulaFunc[In, Out](func(ctx context.Context, runner *Runner[In, Out], bound []Execution[In, Out]) (Verdict[In, Out], error) {
+		var mutex sync.Mutex
+		var first, deciding []Execution[In, Out]
+
+		holds, err := quantifier(ctx, bound, func(execution Execution[In, Out]) (bool, error) {
+			verdict, err := body.Evaluate(ctx, runner, append(slices.Clip(bound), execution))
+			if err == nil {
+				mutex.Lock()
+				if first == nil {
+					first = verdict.Witness
+				}
+				if deciding == nil && verdict.Holds != universal {
+					deciding = verdict.Witness
+				}
+				mutex.Unlock()
+			}
+			return verdict.Holds, err
+		})
+
+		if deciding == nil {
+			deciding = first
+		}
+
+		return Verdict[In, Out]{holds, deciding}, err
+	})
+}
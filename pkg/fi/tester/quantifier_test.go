@@ -0,0 +1,169 @@
+package tester
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperproperties/gorrupt/examples/fissc/VerifyPIN_0/pkg"
+	"github.com/hyperproperties/gorrupt/pkg/fi"
+	"github.com/hyperproperties/gorrupt/pkg/iterx"
+	"github.com/hyperproperties/gorrupt/pkg/obj"
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	pinExecution = Execution[pkg.VerifyPINInput, pkg.VerifyPINOutput]
+	pinRunner    = Runner[pkg.VerifyPINInput, pkg.VerifyPINOutput]
+)
+
+// A runner of VerifyPIN whose emulator is faked by testdata/qemu.sh.
+func fakeRunner(t *testing.T) *pinRunner {
+	qemu, err := filepath.Abs("testdata/qemu.sh")
+	assert.NoError(t, err)
+
+	return NewRunner[pkg.VerifyPINInput, pkg.VerifyPINOutput](
+		qemu, "github.com/hyperproperties/gorrupt/examples/fissc/VerifyPIN_0/pkg", "pkg",
+		"GOARCH=arm", "GOOS=linux",
+	)
+}
+
+// Skips the instructions at the PCs regardless of the binary.
+func skips(pcs ...fi.PC) TargetsOption {
+	return func(*obj.Dump) (targets []fi.Target) {
+		for _, pc := range pcs {
+			targets = append(targets, fi.NewISTarget(pc))
+		}
+		return
+	}
+}
+
+var wrongPIN = pkg.VerifyPINInput{UserPIN: [pkg.PINSize]byte{9, 9, 9, 9}}
+
+func TestQuantifiers(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	runner := fakeRunner(t)
+	directory := t.TempDir()
+	golden := NewQuantifierConfiguration(WithDirectory(directory), WithTimeout(time.Minute))
+	faulted := func(pcs ...fi.PC) QuantifierConfiguration {
+		return NewQuantifierConfiguration(
+			WithDirectory(directory), WithTimeout(time.Minute), WithTargetOptions(skips(pcs...)),
+		)
+	}
+
+	formulas := runner.Formulas()
+	authenticated := formulas.Predicate(func(executions ...pinExecution) (bool, error) {
+		return executions[1].Output.Ret0, nil
+	})
+
+	// There exists a fault authenticating a wrong PIN.
+	verdict, err := formulas.Check(ctx, formulas.Forall(golden, formulas.Over(iterx.Once2(wrongPIN)),
+		formulas.Exists(faulted(0x1000, 0x1004, 0x1008, 0x100c), formulas.Input(0), authenticated),
+	))
+	assert.NoError(t, err)
+	assert.True(t, verdict.Holds)
+	assert.Len(t, verdict.Witness, 2)
+	assert.Equal(t, GoldenOutcome, verdict.Witness[0].Outcome)
+	assert.Equal(t, "[is 4100 0]", verdict.Witness[1].Plan.String())
+	assert.Equal(t, CorruptionOutcome, verdict.Witness[1].Outcome)
+
+	// No fault authenticates a wrong PIN unless the user is authenticated by skipping 0x1004.
+	verdict, err = formulas.Check(ctx, formulas.Forall(golden, formulas.Over(iterx.Once2(wrongPIN)),
+		formulas.Forall(faulted(0x1000, 0x1008, 0x100c), formulas.Input(0), formulas.Not(authenticated)),
+	))
+	assert.NoError(t, err)
+	assert.True(t, verdict.Holds)
+	assert.Len(t, verdict.Witness, 2)
+
+	// Every fault is either masked or detected. The crash at 0x1008 is the counterexample.
+	maskedOrDetected := formulas.Predicate(func(executions ...pinExecution) (bool, error) {
+		outcome := executions[1].Outcome
+		return outcome == GoldenOutcome || outcome == DetectedOutcome, nil
+	})
+	verdict, err = formulas.Check(ctx, formulas.Forall(golden, formulas.Over(iterx.Once2(wrongPIN)),
+		formulas.ForallParallel(faulted(0x1000, 0x1008, 0x100c).Parallel(WithPool(2), WithBatch(8)), formulas.Input(0),
+			formulas.Or(formulas.And(formulas.Not(formulas.Not(maskedOrDetected))), formulas.Or()),
+		),
+	))
+	assert.NoError(t, err)
+	assert.False(t, verdict.Holds)
+	assert.Len(t, verdict.Witness, 2)
+	assert.Equal(t, "[is 4104 0]", verdict.Witness[1].Plan.String())
+	assert.Equal(t, CrashOutcome, verdict.Witness[1].Outcome)
+	assert.Error(t, verdict.Witness[1].Err)
+
+	// Exists directly on the runner.
+	exists, err := runner.Exists(ctx, faulted(0x1000, 0x100c), iterx.Once2(wrongPIN), func(execution pinExecution) (bool, error) {
+		return execution.Outcome == DetectedOutcome, nil
+	})
+	assert.NoError(t, err)
+	assert.True(t, exists.Holds)
+	witness, ok := exists.Witness()
+	assert.True(t, ok)
+	assert.Equal(t, "[is 4096 0]", witness.Plan.String())
+}
+
+func TestResult(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	runner := fakeRunner(t)
+	directory := t.TempDir()
+	configuration := func(options ...QuantifierOption) QuantifierConfiguration {
+		return NewQuantifierConfiguration(append([]QuantifierOption{
+			WithDirectory(directory), WithTimeout(time.Minute),
+			WithTargetOptions(skips(0x1000, 0x1004, 0x1008, 0x100c)),
+		}, options...)...)
+	}
+	maskedOrDetected := func(execution pinExecution) (bool, error) {
+		return execution.Outcome == GoldenOutcome || execution.Outcome == DetectedOutcome, nil
+	}
+
+	// By default the first counterexample terminates the quantifier.
+	result, err := runner.Forall(ctx, configuration(), iterx.Once2(wrongPIN), maskedOrDetected)
+	assert.NoError(t, err)
+	assert.False(t, result.Holds)
+	assert.Len(t, result.Executions, 1)
+	counterexample, ok := result.Counterexample()
+	assert.True(t, ok)
+	assert.Equal(t, "[is 4100 0]", counterexample.Plan.String())
+	assert.Equal(t, CorruptionOutcome, counterexample.Outcome)
+	assert.Equal(t, wrongPIN, counterexample.Input)
+	assert.False(t, counterexample.Golden.Ret0)
+	assert.True(t, counterexample.Output.Ret0)
+
+	// A negative limit collects every counterexample.
+	result, err = runner.Forall(ctx, configuration(WithLimit(-1)), iterx.Once2(wrongPIN), maskedOrDetected)
+	assert.NoError(t, err)
+	assert.False(t, result.Holds)
+	assert.Len(t, result.Executions, 2)
+	assert.Equal(t, CrashOutcome, result.Executions[1].Outcome)
+	assert.Contains(t, result.String(), "[is 4104 0]")
+
+	result, err = runner.ForallParallel(ctx, configuration(WithLimit(-1)).Parallel(WithPool(2), WithBatch(8)),
+		iterx.Once2(wrongPIN), maskedOrDetected)
+	assert.NoError(t, err)
+	assert.False(t, result.Holds)
+	assert.Len(t, result.Executions, 2)
+
+	// A holding universal quantifier has no counterexample.
+	result, err = runner.Forall(ctx, configuration(WithLimit(-1)), iterx.Once2(wrongPIN), func(pinExecution) (bool, error) {
+		return true, nil
+	})
+	assert.NoError(t, err)
+	assert.True(t, result.Holds)
+	_, ok = result.Counterexample()
+	assert.False(t, ok)
+
+	// The witnesses of an existential quantifier.
+	result, err = runner.ExistsParallel(ctx, configuration(WithLimit(2)).Parallel(), iterx.Once2(wrongPIN),
+		func(execution pinExecution) (bool, error) {
+			return execution.Outcome != GoldenOutcome, nil
+		})
+	assert.NoError(t, err)
+	assert.True(t, result.Holds)
+	assert.Len(t, result.Executions, 2)
+}
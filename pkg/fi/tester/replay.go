@@ -0,0 +1,138 @@
+package tester
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperproperties/gorrupt/pkg/fi"
+)
+
+// Environment variables of the attack plan, e.g., "[bfr 10 0 0xdc3d4 0xdc3d8 8192]", and the json encoded input
+// to replay from a test log.
+const (
+	ReplayEnvironment = "GORRUPT_REPLAY"
+	InputEnvironment  = "GORRUPT_INPUT"
+)
+
+var ErrNoReplayInput = fmt.Errorf("replaying an attack plan requires %s or %s", InputEnvironment, SeedEnvironment)
+
+// The outputs of the same binary without and with the attack plan.
+type Replay[In, Out any] struct {
+	Input  In
+	Plan   fi.AttackPlan
+	Golden Out
+	// The output under the attack plan.
+	Faulted Out
+	Outcome Outcome
+	// The error of the execution under the attack plan (if any).
+	Err error
+}
+
+func (replay Replay[In, Out]) String() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "input:   %+v\n", replay.Input)
+	fmt.Fprintf(&builder, "plan:    %s\n", replay.Plan)
+	fmt.Fprintf(&builder, "golden:  %+v\n", replay.Golden)
+	fmt.Fprintf(&builder, "faulted: %+v\n", replay.Faulted)
+	fmt.Fprintf(&builder, "outcome: %s", replay.Outcome)
+	if replay.Err != nil {
+		fmt.Fprintf(&builder, "\nerror:   %v", replay.Err)
+	}
+	return builder.String()
+}
+
+// Finds the attack plan and input to replay from the environment variables. If no input is given then the
+// fallback is used which must be generated from the seed of the campaign (see Generator) since otherwise it
+// differs from the input of the test log. Ok is false if no replay was requested.
+func ReplayRequest[In any](fallback In) (input In, plan fi.AttackPlan, ok bool, err error) {
+	input = fallback
+
+	str := os.Getenv(ReplayEnvironment)
+	if str == "" {
+		return input, nil, false, nil
+	}
+
+	if plan, err = fi.ParseAttackPlan(str); err != nil {
+		return input, nil, true, err
+	}
+
+	encoded := os.Getenv(InputEnvironment)
+	if encoded == "" {
+		if os.Getenv(SeedEnvironment) == "" {
+			return input, plan, true, ErrNoReplayInput
+		}
+		return input, plan, true, nil
+	}
+	if err = json.Unmarshal([]byte(encoded), &input); err != nil {
+		return input, plan, true, err
+	}
+
+	return input, plan, true, nil
+}
+
+// Builds the binary for the input and executes it once without and once with the attack plan.
+func (runner *Runner[In, Out]) Replay(
+	ctx context.Context, configuration QuantifierConfiguration, input In, plan fi.AttackPlan,
+) (Replay[In, Out], error) {
+	replay := Replay[In, Out]{
+		Input: input,
+		Plan:  plan,
+	}
+
+	if err := runner.injector.Supports(ctx, PlanModels(plan)...); err != nil {
+		return replay, err
+	}
+	if err := runner.Prepare(ctx, input, &configuration); err != nil {
+		return replay, err
+	}
+
+	var err error
+	if replay.Golden, err = runner.Golden(ctx, configuration, input); err != nil {
+		return replay, err
+	}
+
+	faulted, err := runner.Run(ctx, configuration, input, replay.Golden, plan)
+	if err != nil {
+		return replay, err
+	}
+	replay.Faulted, replay.Outcome, replay.Err = faulted.Output, faulted.Outcome, faulted.Err
+
+	return replay, nil
+}
+
+// Replays the attack plan requested through the environment variables and logs the outputs. It reports
+// whether a replay was requested such that the test can skip the full campaign and fails the test if the
+// replay is requested without an input or seed. E.g.,
+//
+//	GORRUPT_REPLAY="[bfr 10 0 0xdc3d4 0xdc3d8 8192]" GORRUPT_INPUT='{"UserPIN":[1,2,3,4]}' go test -run Test
+func ReplayTest[In, Out any](
+	t testing.TB, ctx context.Context, runner *Runner[In, Out], fallback In, options ...QuantifierOption,
+) bool {
+	t.Helper()
+
+	input, plan, ok, err := ReplayRequest(fallback)
+	if !ok {
+		return false
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	options = append([]QuantifierOption{
+		WithDirectory(t.TempDir()),
+		WithTimeout(time.Minute),
+	}, options...)
+
+	replay, err := runner.Replay(ctx, NewQuantifierConfiguration(options...), input, plan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log("\n" + replay.String())
+
+	return true
+}
@@ -0,0 +1,93 @@
+package tester
+
+import (
+	"testing"
+
+	"github.com/hyperproperties/gorrupt/examples/fissc/VerifyPIN_0/pkg"
+	"github.com/hyperproperties/gorrupt/pkg/fi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplayRequest(t *testing.T) {
+	fallback := pkg.VerifyPINInput{UserPIN: [4]byte{9, 9, 9, 9}}
+
+	tests := []struct {
+		description string
+		replay      string
+		input       string
+		seed        string
+		expected    pkg.VerifyPINInput
+		plan        fi.AttackPlan
+		ok          bool
+		err         bool
+	}{
+		{
+			description: "no replay",
+			expected:    fallback,
+		},
+		{
+			description: "replay of the input",
+			replay:      "[is 4096 0]",
+			input:       `{"UserPIN":[1,2,3,4]}`,
+			expected:    pkg.VerifyPINInput{UserPIN: [4]byte{1, 2, 3, 4}},
+			plan:        fi.AttackPlan{fi.NewIS(4096, 0)},
+			ok:          true,
+		},
+		{
+			description: "replay without an input",
+			replay:      "[is 4096 0]",
+			expected:    fallback,
+			plan:        fi.AttackPlan{fi.NewIS(4096, 0)},
+			ok:          true,
+			err:         true,
+		},
+		{
+			description: "replay of the seeded fallback",
+			replay:      "[is 4096 0]",
+			seed:        "1234",
+			expected:    fallback,
+			plan:        fi.AttackPlan{fi.NewIS(4096, 0)},
+			ok:          true,
+		},
+		{
+			description: "invalid plan",
+			replay:      "[glitch 4096]",
+			expected:    fallback,
+			ok:          true,
+			err:         true,
+		},
+		{
+			description: "invalid input",
+			replay:      "[is 4096 0]",
+			input:       `{"UserPIN":`,
+			expected:    fallback,
+			plan:        fi.AttackPlan{fi.NewIS(4096, 0)},
+			ok:          true,
+			err:         true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			t.Setenv(ReplayEnvironment, tt.replay)
+			t.Setenv(InputEnvironment, tt.input)
+			t.Setenv(SeedEnvironment, tt.seed)
+
+			input, plan, ok, err := ReplayRequest(fallback)
+			if tt.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, input)
+			assert.Equal(t, tt.plan, plan)
+		})
+	}
+
+	t.Setenv(ReplayEnvironment, "[is 4096 0]")
+	t.Setenv(InputEnvironment, "")
+	t.Setenv(SeedEnvironment, "")
+	_, _, _, err := ReplayRequest(fallback)
+	assert.ErrorIs(t, err, ErrNoReplayInput)
+}
@@ -0,0 +1,81 @@
+package tester
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// The result of a quantifier over the executions of inputs under attack plans.
+type Result[In, Out any] struct {
+	Holds bool
+	// The counterexamples of a universal quantifier or the witnesses of an existential one
+	// in the order they were found. There are at most as many as the limit of the configuration.
+	Executions []Execution[In, Out]
+}
+
+// The first counterexample of a universal quantifier which does not hold.
+func (result Result[In, Out]) Counterexample() (Execution[In, Out], bool) {
+	if len(result.Executions) == 0 {
+		return Execution[In, Out]{}, false
+	}
+	return result.Executions[0], true
+}
+
+// The first witness of an existential quantifier which holds.
+func (result Result[In, Out]) Witness() (Execution[In, Out], bool) {
+	return result.Counterexample()
+}
+
+func (result Result[In, Out]) String() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "holds: %v", result.Holds)
+	for _, execution := range result.Executions {
+		fmt.Fprintf(&builder, "\ninput %+v, plan %s, outcome %s, golden %+v, output %+v",
+			execution.Input, execution.Plan, execution.Outcome, execution.Golden, execution.Output)
+		if execution.Err != nil {
+			fmt.Fprintf(&builder, ", error %v", execution.Err)
+		}
+	}
+	return builder.String()
+}
+
+// Collects the executions of a quantifier up to a limit. It is safe for concurrent use.
+type collector[In, Out any] struct {
+	mutex      sync.Mutex
+	limit      int
+	executions []Execution[In, Out]
+}
+
+func newCollector[In, Out any](limit int) *collector[In, Out] {
+	return &collector[In, Out]{
+		limit: limit,
+	}
+}
+
+// Adds the execution unless the limit is reached and reports whether it is reached afterwards.
+func (collector *collector[In, Out]) add(execution Execution[In, Out]) bool {
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+
+	if collector.limit < 0 || len(collector.executions) < collector.limit {
+		collector.executions = append(collector.executions, execution)
+	}
+	return collector.limit >= 0 && len(collector.executions) >= collector.limit
+}
+
+func (collector *collector[In, Out]) reached() bool {
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+	return collector.limit >= 0 && len(collector.executions) >= collector.limit
+}
+
+// The result of a universal quantifier which holds if no counterexamples were collected.
+func (collector *collector[In, Out]) result() Result[In, Out] {
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+	return Result[In, Out]{
+		Holds:      len(collector.executions) == 0,
+		Executions: collector.executions,
+	}
+}
@@ -2,7 +2,9 @@ package tester
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -11,12 +13,12 @@ import (
 	"os"
 	"os/exec"
 	"path"
+	"path/filepath"
 	"reflect"
 	"sync/atomic"
 	"time"
 
 	"github.com/alitto/pond/v2"
-	"github.com/hyperproperties/gorrupt/pkg/execx"
 	"github.com/hyperproperties/gorrupt/pkg/fi"
 	"github.com/hyperproperties/gorrupt/pkg/obj"
 )
@@ -24,25 +26,48 @@ import (
 type Runner[In, Out any] struct {
 	counter atomic.Uint64
 
-	// The path the qemu binary to run the fault injections.
-	qemu string
+	// The backend executing the binary under attack plans.
+	injector Injector
 	// The build environment.
 	environment []string
 	// The required import for the generation.
 	imp string
 	// The package from which the input/outpus are accessed.
 	pkg string
+	// The name of the input type in the package. If it is empty then it is the name of In.
+	input string
 }
 
 func NewRunner[In, Out any](qemu string, imp, pkg string, environment ...string) *Runner[In, Out] {
+	return NewNamedRunner[In, Out](qemu, imp, pkg, "", environment...)
+}
+
+// Creates a runner like NewRunner where the input type in the package is named explicitly. This is required
+// when In is not that type, e.g., json.RawMessage if the input type is only known at runtime.
+func NewNamedRunner[In, Out any](qemu string, imp, pkg, input string, environment ...string) *Runner[In, Out] {
+	return NewQEMURunner[In, Out](NewQEMU(qemu), imp, pkg, input, environment...)
+}
+
+// Creates a runner like NewNamedRunner with a located emulator, see LocateQEMU.
+func NewQEMURunner[In, Out any](qemu *QEMU, imp, pkg, input string, environment ...string) *Runner[In, Out] {
+	return NewInjectorRunner[In, Out](qemu, imp, pkg, input, environment...)
+}
+
+// Creates a runner like NewNamedRunner whose executions are made by the injector, e.g., a FakeInjector.
+func NewInjectorRunner[In, Out any](injector Injector, imp, pkg, input string, environment ...string) *Runner[In, Out] {
 	return &Runner[In, Out]{
-		qemu:        qemu,
+		injector:    injector,
 		environment: environment,
 		imp:         imp,
 		pkg:         pkg,
+		input:       input,
 	}
 }
 
+func (runner *Runner[In, Out]) Injector() Injector {
+	return runner.injector
+}
+
 func (runner *Runner[In, Out]) uniqueString() string {
 	value := runner.counter.Add(1)
 	return fmt.Sprintf("%v", value)
@@ -51,22 +76,18 @@ func (runner *Runner[In, Out]) uniqueString() string {
 // Generates the main which calls the function under attack.
 // The main function (entry point) handles un-/marshalling of the inputs and outputs.
 // The input is marshalled with json into a byte slice which is directly inserted into the generated main.
+// The main is named by its content such that the binary of an input is the same across builds and
+// its records in a store are found again, e.g., when resuming a campaign.
 func (runner *Runner[In, Out]) Generate(context context.Context, dir string, input In) (string, error) {
-	name := runner.uniqueString() + "-main.go"
-	filepath := path.Join(dir, name)
-
-	file, err := os.OpenFile(filepath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
-	if err != nil {
-		return "", err
-	}
-	defer file.Close()
-
 	bytes, err := json.Marshal(input)
 	if err != nil {
 		return "", err
 	}
 
-	inputName := reflect.TypeOf(input).Name()
+	inputName := runner.input
+	if inputName == "" {
+		inputName = reflect.TypeOf(input).Name()
+	}
 
 	main := fmt.Sprintf(`package main
 
@@ -92,14 +113,31 @@ func main() {
 }
 `, runner.imp, runner.pkg, inputName, bytes)
 
-	if _, err := file.WriteString(main); err != nil {
+	sum := sha256.Sum256([]byte(main))
+	filepath := path.Join(dir, hex.EncodeToString(sum[:8])+"-main.go")
+	if _, err := os.Stat(filepath); err == nil {
+		return filepath, nil
+	}
+
+	// The main is renamed into place such that concurrent generations never build a partial main.
+	file, err := os.CreateTemp(dir, "*-main.go.tmp")
+	if err != nil {
 		return "", err
 	}
+	if _, err := file.WriteString(main); err != nil {
+		file.Close()
+		return "", errors.Join(err, os.Remove(file.Name()))
+	}
+	if err := file.Close(); err != nil {
+		return "", errors.Join(err, os.Remove(file.Name()))
+	}
 
-	return filepath, nil
+	return filepath, os.Rename(file.Name(), filepath)
 }
 
 // Creates the attack file (configuration) for qemu.
+//
+// Deprecated: The attack file is written by QEMU.Execute.
 func (runner *Runner[In, Out]) Configure(context context.Context, dir string, plan fi.AttackPlan) (string, error) {
 	name := runner.uniqueString() + "-fi"
 	filepath := path.Join(dir, name)
@@ -118,6 +156,8 @@ func (runner *Runner[In, Out]) Configure(context context.Context, dir string, pl
 }
 
 // Writes the objdump from the build.
+//
+// Deprecated: Use obj.LoadFile on the binary which disassembles it without a subprocess.
 func (runner *Runner[In, Out]) Dump(context context.Context, dir, binary string) (string, error) {
 	name := runner.uniqueString() + "-objdump"
 	filepath := path.Join(dir, name)
@@ -146,31 +186,22 @@ func (runner *Runner[In, Out]) Build(context context.Context, dir, main string)
 
 // Runs the generated entry-point for the binary which is under attack.
 // Before executing the entry-point must be generated and build.
+// If the binary does not finish with a decodable output then the error is an *ExecutionError.
+//
+// Deprecated: Use Execute which runs the attack plan by the injector of the runner.
 func (runner *Runner[In, Out]) QEMU(ctx context.Context, binary, attack string) (Out, error) {
-	var output []byte
-	err := execx.RunCommandContext(ctx, func(command *exec.Cmd) (err error) {
-		output, err = command.CombinedOutput()
-		return err
-	}, "sh", "-c", runner.qemu+" -fi "+attack+" "+binary+" -no-shutdown -no-reboot")
-
-	if err != nil {
-		var configuration Out
-		return configuration, err
+	var zero Out
+	qemu, ok := runner.injector.(*QEMU)
+	if !ok {
+		return zero, errors.New("the injector of the runner is not qemu")
 	}
 
-	bytes, err := base64.StdEncoding.DecodeString(string(output))
+	output, err := qemu.Run(ctx, attack, binary)
 	if err != nil {
-		var configuration Out
-		return configuration, err
-	}
-
-	var result Out
-	if err := json.Unmarshal(bytes, &result); err != nil {
-		var configuration Out
-		return configuration, err
+		return zero, err
 	}
 
-	return result, nil
+	return decode[Out](output)
 }
 
 func (runner *Runner[In, Out]) Go(context context.Context, main string) (Out, error) {
@@ -205,12 +236,38 @@ func WithTargetOptions(options ...TargetsOption) QuantifierOption {
 	}
 }
 
+// Options for the planner which combines the targets into attack plans.
+func WithPlannerOptions(options ...fi.PlannerOption) QuantifierOption {
+	return func(configuration *QuantifierConfiguration) {
+		configuration.planner = append(configuration.planner, options...)
+	}
+}
+
 func WithDirectory(directory string) QuantifierOption {
 	return func(configuration *QuantifierConfiguration) {
 		configuration.directory = directory
 	}
 }
 
+// Persists every execution to the store with the name in the campaign directory such that an interrupted
+// campaign resumes without executing the plans again. If the name is empty then it is StoreName.
+func WithStore(name string) QuantifierOption {
+	return func(configuration *QuantifierConfiguration) {
+		if name == "" {
+			name = StoreName
+		}
+		configuration.store = name
+	}
+}
+
+// The number of counterexamples (or witnesses) a quantifier collects before it terminates.
+// The default is 1 and a negative limit collects all of them.
+func WithLimit(limit int) QuantifierOption {
+	return func(configuration *QuantifierConfiguration) {
+		configuration.limit = limit
+	}
+}
+
 func WithTimeout(timeout time.Duration) QuantifierOption {
 	return func(configuration *QuantifierConfiguration) {
 		configuration.timeout = timeout
@@ -224,7 +281,13 @@ type QuantifierConfiguration struct {
 	landfill  string
 	dump      *obj.Dump
 	targets   []TargetsOption
+	planner   []fi.PlannerOption
 	timeout   time.Duration
+	limit     int
+	store     string
+	records   *Store
+	// The hash of the binary which keys its records in the store.
+	hash string
 }
 
 func NewQuantifierConfiguration(options ...QuantifierOption) QuantifierConfiguration {
@@ -267,30 +330,167 @@ func (configuration QuantifierConfiguration) HasTimeout() bool {
 	return configuration.timeout > 0
 }
 
+func (configuration QuantifierConfiguration) Limit() int {
+	if configuration.limit == 0 {
+		return 1
+	}
+	return configuration.limit
+}
+
+func (configuration QuantifierConfiguration) HasStore() bool {
+	return len(configuration.store) > 0
+}
+
+// The path of the store. Relative names are in the campaign directory.
+func (configuration QuantifierConfiguration) StorePath() string {
+	if filepath.IsAbs(configuration.store) {
+		return configuration.store
+	}
+	return path.Join(configuration.directory, configuration.store)
+}
+
+// Opens the store if the configuration has one. The returned function closes it again.
+func (configuration *QuantifierConfiguration) openStore() (func() error, error) {
+	if !configuration.HasStore() || configuration.records != nil {
+		return func() error { return nil }, nil
+	}
+
+	store, err := OpenStore(configuration.StorePath())
+	if err != nil {
+		return nil, err
+	}
+	configuration.records = store
+
+	return func() error {
+		configuration.records = nil
+		return store.Close()
+	}, nil
+}
+
 func (configuration QuantifierConfiguration) Targets() []TargetsOption {
 	return configuration.targets
 }
 
+func (configuration QuantifierConfiguration) PlannerOptions() []fi.PlannerOption {
+	return configuration.planner
+}
+
+// The hash of the prepared binary which is only computed if the configuration has a store.
+func (configuration QuantifierConfiguration) Hash() string {
+	return configuration.hash
+}
+
 func (configuration QuantifierConfiguration) Dump() *obj.Dump {
 	return configuration.dump
 }
 
+// Executes the binary under the attack plan by the injector and decodes its output.
+// If the binary does not finish with a decodable output then the error is an *ExecutionError.
 func (runner *Runner[In, Out]) Execute(
 	context context.Context, directory string, binary string, plan fi.AttackPlan,
 ) (Out, error) {
-	attack, err := runner.Configure(context, directory, plan)
+	output, err := runner.injector.Execute(context, directory, binary, plan)
 	if err != nil {
 		var zero Out
 		return zero, err
 	}
 
-	output, err := runner.QEMU(context, binary, attack)
+	return decode[Out](output)
+}
 
-	if rmErr := os.Remove(attack); rmErr != nil {
-		return output, errors.Join(rmErr, err)
+// Executes the prepared binary without any attack.
+func (runner *Runner[In, Out]) Golden(ctx context.Context, configuration QuantifierConfiguration, input In) (Out, error) {
+	var golden Out
+	if execution, ok, err := recorded(configuration, input, golden, fi.AttackPlan{}); ok || err != nil {
+		return execution.Output, err
 	}
 
-	return output, err
+	execCTX, cancel := context.WithTimeout(ctx, configuration.timeout)
+	output, err := runner.Execute(execCTX, configuration.directory, configuration.binary, fi.AttackPlan{})
+	cancel()
+	if err != nil {
+		return output, err
+	}
+
+	execution := Execution[In, Out]{
+		Input:   input,
+		Plan:    fi.AttackPlan{},
+		Golden:  output,
+		Output:  output,
+		Outcome: GoldenOutcome,
+	}
+	return output, record(configuration, execution)
+}
+
+// Executes the prepared binary under the attack plan and classifies its outcome.
+// The error is only returned if the execution could not be attempted, e.g., if the attack file could not be written.
+func (runner *Runner[In, Out]) Run(
+	ctx context.Context, configuration QuantifierConfiguration, input In, golden Out, plan fi.AttackPlan,
+) (Execution[In, Out], error) {
+	if execution, ok, err := recorded(configuration, input, golden, plan); ok || err != nil {
+		return execution, err
+	}
+
+	execution := Execution[In, Out]{
+		Input:  input,
+		Plan:   plan,
+		Golden: golden,
+	}
+
+	execCTX, cancel := context.WithTimeout(ctx, configuration.timeout)
+	output, err := runner.Execute(execCTX, configuration.directory, configuration.binary, plan)
+	cancel()
+
+	// Only the timeout of the execution is an outcome. If the campaign is cancelled or
+	// runs out of time then the execution is not recorded such that it is resumed.
+	if err := ctx.Err(); err != nil {
+		return execution, err
+	}
+
+	var failed *ExecutionError
+	switch {
+	case errors.As(err, &failed):
+		execution.Outcome = failed.Outcome
+		execution.Err = err
+	case err != nil:
+		return execution, err
+	default:
+		execution.Output = output
+		execution.Outcome = Classify(golden, output)
+	}
+
+	return execution, record(configuration, execution)
+}
+
+// Finds the execution of the plan in the store of the configuration (if any).
+func recorded[In, Out any](
+	configuration QuantifierConfiguration, input In, golden Out, plan fi.AttackPlan,
+) (Execution[In, Out], bool, error) {
+	if configuration.records == nil {
+		return Execution[In, Out]{}, false, nil
+	}
+
+	record, ok := configuration.records.Lookup(configuration.hash, plan)
+	if !ok {
+		return Execution[In, Out]{}, false, nil
+	}
+
+	execution, err := RecordExecution(record, input, golden, plan)
+	return execution, true, err
+}
+
+// Adds the execution to the store of the configuration (if any).
+func record[In, Out any](configuration QuantifierConfiguration, execution Execution[In, Out]) error {
+	if configuration.records == nil {
+		return nil
+	}
+
+	record, err := NewRecord(configuration.hash, execution)
+	if err != nil {
+		return err
+	}
+
+	return configuration.records.Add(record)
 }
 
 func (runner *Runner[In, Out]) Prepare(
@@ -311,56 +511,89 @@ func (runner *Runner[In, Out]) Prepare(
 
 	}
 
-	if !configuration.HasLandfill() {
-		var err error
-		if configuration.landfill, err = runner.Dump(context, configuration.directory, configuration.binary); err != nil {
-			return err
-		}
+	if err := runner.injector.Prepare(context, configuration.binary); err != nil {
+		return err
 	}
 
 	if !configuration.HasDump() {
-		dump, err := obj.ParseFile(configuration.landfill)
+		dump, err := obj.LoadFile(configuration.binary)
 		if err != nil {
 			return err
 		}
 		configuration.dump = &dump
 	}
 
+	if configuration.HasStore() && configuration.hash == "" {
+		var err error
+		if configuration.hash, err = HashFile(configuration.binary); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// Checks if the predicate holds for every execution of the inputs under the attack plans.
+// It terminates when the limit of counterexamples is reached or the predicate returns an error.
 func (runner *Runner[In, Out]) Forall(
 	ctx context.Context,
 	configuration QuantifierConfiguration,
 	inputs iter.Seq2[int, In],
-	predicate func(input In, output Out, plan fi.AttackPlan) (bool, error),
-) (bool, error) {
-	planner := fi.NewAttackPlanner()
+	predicate func(execution Execution[In, Out]) (bool, error),
+) (Result[In, Out], error) {
+	counterexamples := newCollector[In, Out](configuration.Limit())
+
+	closeStore, err := configuration.openStore()
+	if err != nil {
+		return counterexamples.result(), err
+	}
+	defer closeStore()
+
+	planner := fi.NewAttackPlanner(configuration.PlannerOptions()...)
+	base := configuration
 	for _, input := range inputs {
-		runner.Prepare(ctx, input, &configuration)
+		// The input is part of the generated main and therefore every input has its own binary.
+		configuration := base
+		if err := runner.Prepare(ctx, input, &configuration); err != nil {
+			return counterexamples.result(), err
+		}
 		targets := make([]fi.Target, 0)
 		for _, option := range configuration.Targets() {
 			targets = append(targets, option(configuration.Dump())...)
 		}
+		if err := runner.injector.Supports(ctx, TargetModels(targets...)...); err != nil {
+			return counterexamples.result(), err
+		}
+
+		golden, err := runner.Golden(ctx, configuration, input)
+		if err != nil {
+			return counterexamples.result(), err
+		}
 
 		for _, plan := range planner.Plan(targets...) {
-			execCTX, cancel := context.WithTimeout(ctx, configuration.timeout)
-			output, err := runner.Execute(
-				execCTX, configuration.directory, configuration.binary, plan,
-			)
-			cancel()
+			execution := Execution[In, Out]{
+				Input:   input,
+				Plan:    plan,
+				Golden:  golden,
+				Output:  golden,
+				Outcome: GoldenOutcome,
+			}
 
-			if err != nil {
-				return true, err
+			// The golden execution is reused for the empty plan.
+			if len(plan) > 0 {
+				if execution, err = runner.Run(ctx, configuration, input, golden, plan); err != nil {
+					return counterexamples.result(), err
+				}
 			}
 
-			if ok, err := predicate(input, output, plan); !ok {
-				return false, err
+			ok, err := predicate(execution)
+			if !ok && counterexamples.add(execution) || err != nil {
+				return counterexamples.result(), err
 			}
 		}
 	}
 
-	return true, nil
+	return counterexamples.result(), nil
 }
 
 type ParallelQuantifierOption func(configuration *ParallelQuantifierConfiguration)
@@ -392,69 +625,86 @@ func NewParallelQuantifierConfiguration(base QuantifierConfiguration, options ..
 	return configuration
 }
 
+// Checks like Forall but executes the attack plans in parallel.
+// It terminates at the end of the batch in which the limit of counterexamples is reached.
 func (runner *Runner[In, Out]) ForallParallel(
 	ctx context.Context,
 	configuration ParallelQuantifierConfiguration,
 	inputs iter.Seq2[int, In],
-	predicate func(input In, output Out, plan fi.AttackPlan) (bool, error),
-) (bool, error) {
+	predicate func(execution Execution[In, Out]) (bool, error),
+) (Result[In, Out], error) {
+	counterexamples := newCollector[In, Out](configuration.Limit())
+
+	closeStore, err := configuration.openStore()
+	if err != nil {
+		return counterexamples.result(), err
+	}
+	defer closeStore()
+
 	pool := pond.NewResultPool[bool](configuration.pool, pond.WithContext(ctx))
 	defer pool.StopAndWait()
 
 	var counter atomic.Int32
 	group := pool.NewGroup()
 
-	planner := fi.NewAttackPlanner()
+	planner := fi.NewAttackPlanner(configuration.PlannerOptions()...)
+	base := configuration
 	for _, input := range inputs {
-		runner.Prepare(ctx, input, &configuration.QuantifierConfiguration)
+		// The input is part of the generated main and therefore every input has its own binary.
+		configuration := base
+		if err := runner.Prepare(ctx, input, &configuration.QuantifierConfiguration); err != nil {
+			return counterexamples.result(), err
+		}
 		targets := make([]fi.Target, 0)
 		for _, option := range configuration.Targets() {
 			targets = append(targets, option(configuration.Dump())...)
 		}
+		if err := runner.injector.Supports(ctx, TargetModels(targets...)...); err != nil {
+			return counterexamples.result(), err
+		}
+
+		golden, err := runner.Golden(ctx, configuration.QuantifierConfiguration, input)
+		if err != nil {
+			return counterexamples.result(), err
+		}
 
 		for _, plan := range planner.Plan(targets...) {
 			counter.Add(1)
-			group.Submit(func() bool {
+			group.SubmitErr(func() (bool, error) {
 				defer counter.Add(-1)
 
-				execCTX, cancel := context.WithTimeout(ctx, configuration.timeout)
-				output, err := runner.Execute(
-					execCTX, configuration.directory, configuration.binary, plan,
-				)
-				cancel()
-
-				if err != nil {
-					return true
+				execution := Execution[In, Out]{
+					Input:   input,
+					Plan:    plan,
+					Golden:  golden,
+					Output:  golden,
+					Outcome: GoldenOutcome,
 				}
 
-				if ok, _ := predicate(input, output, plan); !ok {
-					return false
+				// The golden execution is reused for the empty plan.
+				if len(plan) > 0 {
+					var err error
+					if execution, err = runner.Run(ctx, configuration.QuantifierConfiguration, input, golden, plan); err != nil {
+						return true, err
+					}
 				}
 
-				if err := recover(); err != nil {
-					return false
+				ok, err := predicate(execution)
+				if !ok && counterexamples.add(execution) {
+					return false, err
 				}
 
-				return true
+				return true, err
 			})
 
 			if counter.Load() >= configuration.batch {
-				results, err := group.Wait()
-				for _, result := range results {
-					if !result {
-						return false, err
-					}
+				if _, err := group.Wait(); err != nil || counterexamples.reached() {
+					return counterexamples.result(), err
 				}
 			}
 		}
 	}
 
-	results, err := group.Wait()
-	for _, result := range results {
-		if !result {
-			return false, err
-		}
-	}
-
-	return true, nil
+	_, err = group.Wait()
+	return counterexamples.result(), err
 }
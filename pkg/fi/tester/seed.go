@@ -0,0 +1,42 @@
+package tester
+
+import (
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/hyperproperties/gorrupt/pkg/quick"
+)
+
+// Environment variable of the seed of the generator of inputs and names to reproduce a campaign from a test log.
+const SeedEnvironment = "GORRUPT_SEED"
+
+// The generator of a campaign seeded from the environment variable. If no seed is given then it is random.
+func Generator() (*quick.Generator, error) {
+	str := os.Getenv(SeedEnvironment)
+	if str == "" {
+		return quick.NewRandomGenerator(), nil
+	}
+
+	seed, err := strconv.ParseUint(str, 0, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return quick.NewGenerator(seed), nil
+}
+
+// The generator of a campaign whose seed is logged such that the campaign is reproduced bit-for-bit, e.g.,
+//
+//	GORRUPT_SEED=1234 go test -run Test
+func GeneratorTest(t testing.TB) *quick.Generator {
+	t.Helper()
+
+	generator, err := Generator()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("seed: %d (reproduce with %s=%d)", generator.Seed(), SeedEnvironment, generator.Seed())
+
+	return generator
+}
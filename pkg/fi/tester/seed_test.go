@@ -0,0 +1,28 @@
+package tester
+
+import (
+	"testing"
+
+	"github.com/hyperproperties/gorrupt/examples/fissc/VerifyPIN_0/pkg"
+	"github.com/hyperproperties/gorrupt/pkg/quick"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerator(t *testing.T) {
+	t.Setenv(SeedEnvironment, "1234")
+	generator := GeneratorTest(t)
+	assert.Equal(t, uint64(1234), generator.Seed())
+	assert.Equal(t,
+		quick.Generate[pkg.VerifyPINInput](quick.NewGenerator(1234)),
+		quick.Generate[pkg.VerifyPINInput](generator),
+	)
+
+	t.Setenv(SeedEnvironment, "0x10")
+	generator, err := Generator()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(16), generator.Seed())
+
+	t.Setenv(SeedEnvironment, "seed")
+	_, err = Generator()
+	assert.Error(t, err)
+}
@@ -0,0 +1,96 @@
+package tester
+
+import (
+	"context"
+
+	"github.com/hyperproperties/gorrupt/pkg/fi"
+	"github.com/hyperproperties/gorrupt/pkg/quick"
+)
+
+// Shrinks the attack plan of the counterexample to a 1-minimal plan with 1-minimal masks under which the
+// predicate still does not hold. The smaller plans are executed on the binary built for the input of the
+// counterexample and the execution of the shrunk plan is returned.
+func (runner *Runner[In, Out]) Shrink(
+	ctx context.Context,
+	configuration QuantifierConfiguration,
+	counterexample Execution[In, Out],
+	predicate func(execution Execution[In, Out]) (bool, error),
+) (Execution[In, Out], error) {
+	closeStore, err := configuration.openStore()
+	if err != nil {
+		return counterexample, err
+	}
+	defer closeStore()
+
+	if err := runner.Prepare(ctx, counterexample.Input, &configuration); err != nil {
+		return counterexample, err
+	}
+
+	executions := map[string]Execution[In, Out]{
+		counterexample.Plan.String(): counterexample,
+	}
+	plan, err := fi.Shrink(counterexample.Plan, func(plan fi.AttackPlan) (bool, error) {
+		execution, err := runner.Run(ctx, configuration, counterexample.Input, counterexample.Golden, plan)
+		if err != nil {
+			return false, err
+		}
+
+		ok, err := predicate(execution)
+		if err != nil {
+			return false, err
+		}
+		executions[plan.String()] = execution
+
+		return !ok, nil
+	})
+	if err != nil {
+		return counterexample, err
+	}
+
+	return executions[plan.String()], nil
+}
+
+// Shrinks the input of the counterexample to the smallest input (by quick.Shrink) for which the predicate still
+// does not hold under the same attack plan. Every candidate input is built into its own binary like in Forall,
+// so the configuration must not be prepared for an input yet, and the execution of the shrunk input is returned.
+func (runner *Runner[In, Out]) ShrinkInput(
+	ctx context.Context,
+	configuration QuantifierConfiguration,
+	counterexample Execution[In, Out],
+	predicate func(execution Execution[In, Out]) (bool, error),
+) (Execution[In, Out], error) {
+	closeStore, err := configuration.openStore()
+	if err != nil {
+		return counterexample, err
+	}
+	defer closeStore()
+
+	shrunk := counterexample
+	base := configuration
+	_, err = quick.Shrink(counterexample.Input, func(input In) (bool, error) {
+		configuration := base
+		if err := runner.Prepare(ctx, input, &configuration); err != nil {
+			return false, err
+		}
+
+		golden, err := runner.Golden(ctx, configuration, input)
+		if err != nil {
+			return false, err
+		}
+
+		execution, err := runner.Run(ctx, configuration, input, golden, counterexample.Plan)
+		if err != nil {
+			return false, err
+		}
+
+		ok, err := predicate(execution)
+		if err != nil || ok {
+			return false, err
+		}
+		shrunk = execution
+
+		return true, nil
+	})
+
+	return shrunk, err
+}
@@ -0,0 +1,90 @@
+package tester
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hyperproperties/gorrupt/examples/fissc/VerifyPIN_0/pkg"
+	"github.com/hyperproperties/gorrupt/pkg/fi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShrink(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	runner := fakeRunner(t)
+	configuration := NewQuantifierConfiguration(WithDirectory(t.TempDir()), WithTimeout(time.Minute))
+	rejected := func(execution pinExecution) (bool, error) {
+		return !execution.Output.Ret0, nil
+	}
+
+	tests := []struct {
+		description string
+		plan        fi.AttackPlan
+		shrunk      string
+	}{
+		{
+			description: "redundant attacks",
+			plan:        fi.AttackPlan{fi.NewIS(0x1008, 0), fi.NewIS(0x1004, 0), fi.NewIS(0x100c, 0)},
+			shrunk:      "[is 4100 0]",
+		},
+		{
+			description: "wide mask",
+			plan:        fi.AttackPlan{fi.NewIS(0x100c, 0), fi.NewBFR(0, 0, 0x1000, 0x1004, 0xff)},
+			shrunk:      "[bfr 0 0 0x1000 0x1004 4]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			replay, err := runner.Replay(ctx, configuration, wrongPIN, tt.plan)
+			assert.NoError(t, err)
+			assert.True(t, replay.Faulted.Ret0)
+
+			counterexample := pinExecution{
+				Input:   wrongPIN,
+				Plan:    tt.plan,
+				Golden:  replay.Golden,
+				Output:  replay.Faulted,
+				Outcome: replay.Outcome,
+			}
+			shrunk, err := runner.Shrink(ctx, configuration, counterexample, rejected)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.shrunk, shrunk.Plan.String())
+			assert.Equal(t, CorruptionOutcome, shrunk.Outcome)
+			assert.True(t, shrunk.Output.Ret0)
+		})
+	}
+}
+
+func TestShrinkInput(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	runner := fakeRunner(t)
+	configuration := NewQuantifierConfiguration(WithDirectory(t.TempDir()), WithTimeout(time.Minute))
+	plan := fi.AttackPlan{fi.NewIS(0x1004, 0)}
+
+	// The property is only violated for PINs starting with a digit of at least 3.
+	property := func(execution pinExecution) (bool, error) {
+		return execution.Input.UserPIN[0] < 3 || !execution.Output.Ret0, nil
+	}
+
+	replay, err := runner.Replay(ctx, configuration, wrongPIN, plan)
+	assert.NoError(t, err)
+	counterexample := pinExecution{
+		Input:   wrongPIN,
+		Plan:    plan,
+		Golden:  replay.Golden,
+		Output:  replay.Faulted,
+		Outcome: replay.Outcome,
+	}
+
+	shrunk, err := runner.ShrinkInput(ctx, configuration, counterexample, property)
+	assert.NoError(t, err)
+	assert.Equal(t, [pkg.PINSize]byte{3, 0, 0, 0}, shrunk.Input.UserPIN)
+	assert.Equal(t, plan, shrunk.Plan)
+	assert.Equal(t, CorruptionOutcome, shrunk.Outcome)
+}
@@ -0,0 +1,208 @@
+package tester
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"iter"
+	"os"
+	"sync"
+
+	"github.com/hyperproperties/gorrupt/pkg/fi"
+)
+
+// The name of the store in the campaign directory used by WithStore("").
+const StoreName = "store.jsonl"
+
+// A record of an execution in the store. It is keyed by the hash of the binary and the attack plan.
+type Record struct {
+	Binary  string          `json:"binary"`
+	Plan    string          `json:"plan"`
+	Input   json.RawMessage `json:"input"`
+	Outcome Outcome         `json:"outcome"`
+	Output  json.RawMessage `json:"output,omitempty"`
+	Error   string          `json:"error,omitempty"`
+}
+
+func NewRecord[In, Out any](binary string, execution Execution[In, Out]) (Record, error) {
+	record := Record{
+		Binary:  binary,
+		Plan:    execution.Plan.String(),
+		Outcome: execution.Outcome,
+	}
+
+	var err error
+	if record.Input, err = json.Marshal(execution.Input); err != nil {
+		return record, err
+	}
+
+	if execution.Outcome.HasOutput() {
+		if record.Output, err = json.Marshal(execution.Output); err != nil {
+			return record, err
+		}
+	}
+
+	if execution.Err != nil {
+		record.Error = execution.Err.Error()
+	}
+
+	return record, nil
+}
+
+// Restores the execution of the record for the input, golden output, and plan it was recorded for.
+func RecordExecution[In, Out any](record Record, input In, golden Out, plan fi.AttackPlan) (Execution[In, Out], error) {
+	execution := Execution[In, Out]{
+		Input:   input,
+		Plan:    plan,
+		Golden:  golden,
+		Outcome: record.Outcome,
+	}
+
+	if record.Outcome.HasOutput() {
+		if err := json.Unmarshal(record.Output, &execution.Output); err != nil {
+			return execution, err
+		}
+	}
+
+	if record.Error != "" {
+		execution.Err = NewExecutionError(record.Outcome, nil, errors.New(record.Error))
+	}
+
+	return execution, nil
+}
+
+type storeKey struct {
+	binary, plan string
+}
+
+// Store persists the records of executions to a JSON Lines file such that an interrupted campaign can
+// resume without executing the plans again. It is safe for concurrent use.
+type Store struct {
+	mutex   sync.Mutex
+	file    *os.File
+	records map[storeKey]Record
+	order   []storeKey
+}
+
+// Opens the store at the path and reads its records. The file is created if it does not exist.
+// A partially written (last) record of an interrupted campaign is ignored.
+func OpenStore(path string) (*Store, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	store := &Store{
+		file:    file,
+		records: make(map[storeKey]Record),
+	}
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(nil, len(content)+1)
+	for scanner.Scan() {
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			continue
+		}
+		store.put(record)
+	}
+
+	// Terminates a partially written record such that it is not joined with the next.
+	if len(content) > 0 && content[len(content)-1] != '\n' {
+		if _, err := file.WriteString("\n"); err != nil {
+			file.Close()
+			return nil, err
+		}
+	}
+
+	return store, nil
+}
+
+func (store *Store) put(record Record) {
+	key := storeKey{record.Binary, record.Plan}
+	if _, ok := store.records[key]; !ok {
+		store.order = append(store.order, key)
+	}
+	store.records[key] = record
+}
+
+// Finds the record of the plan executed on the binary with the hash.
+func (store *Store) Lookup(binary string, plan fi.AttackPlan) (Record, bool) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	record, ok := store.records[storeKey{binary, plan.String()}]
+	return record, ok
+}
+
+// Appends the record to the store.
+func (store *Store) Add(record Record) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if _, err := store.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	store.put(record)
+
+	return nil
+}
+
+// The records in the order they were first added.
+func (store *Store) Records() iter.Seq[Record] {
+	return func(yield func(Record) bool) {
+		store.mutex.Lock()
+		records := make([]Record, len(store.order))
+		for idx, key := range store.order {
+			records[idx] = store.records[key]
+		}
+		store.mutex.Unlock()
+
+		for _, record := range records {
+			if !yield(record) {
+				return
+			}
+		}
+	}
+}
+
+func (store *Store) Len() int {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	return len(store.records)
+}
+
+func (store *Store) Close() error {
+	return store.file.Close()
+}
+
+// The SHA-256 hash of the file at the path, e.g., of the binary under attack.
+func HashFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,132 @@
+package tester
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/hyperproperties/gorrupt/examples/fissc/VerifyPIN_0/pkg"
+	"github.com/hyperproperties/gorrupt/pkg/fi"
+	"github.com/hyperproperties/gorrupt/pkg/iterx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore(t *testing.T) {
+	filepath := path.Join(t.TempDir(), StoreName)
+	plan := fi.AttackPlan{fi.NewIS(0x10, 0)}
+	golden := output{Value: 1}
+
+	executions := []Execution[int, output]{
+		{Input: 3, Plan: fi.AttackPlan{}, Golden: golden, Output: golden, Outcome: GoldenOutcome},
+		{Input: 3, Plan: plan, Golden: golden, Output: output{Value: 2}, Outcome: CorruptionOutcome},
+		{Input: 3, Plan: fi.AttackPlan{fi.NewIS(0x14, 0)}, Golden: golden, Outcome: CrashOutcome, Err: errors.New("exit status 2")},
+	}
+
+	store, err := OpenStore(filepath)
+	assert.NoError(t, err)
+	for _, execution := range executions {
+		record, err := NewRecord("abc", execution)
+		assert.NoError(t, err)
+		assert.NoError(t, store.Add(record))
+	}
+	assert.NoError(t, store.Close())
+
+	// An interrupted campaign leaves a partial record behind.
+	file, err := os.OpenFile(filepath, os.O_APPEND|os.O_WRONLY, 0644)
+	assert.NoError(t, err)
+	_, err = file.WriteString(`{"binary":"abc","plan":"[is 0x`)
+	assert.NoError(t, err)
+	assert.NoError(t, file.Close())
+
+	store, err = OpenStore(filepath)
+	assert.NoError(t, err)
+	defer store.Close()
+	assert.Equal(t, 3, store.Len())
+
+	_, ok := store.Lookup("def", plan)
+	assert.False(t, ok)
+
+	for _, expected := range executions {
+		record, ok := store.Lookup("abc", expected.Plan)
+		assert.True(t, ok)
+
+		execution, err := RecordExecution(record, expected.Input, golden, expected.Plan)
+		assert.NoError(t, err)
+		assert.Equal(t, expected.Outcome, execution.Outcome)
+		assert.Equal(t, expected.Output, execution.Output)
+		if expected.Err != nil {
+			assert.EqualError(t, execution.Err, "crash: exit status 2")
+		}
+	}
+
+	// Records added after the partial record are read again.
+	record, err := NewRecord("abc", Execution[int, output]{Input: 4, Plan: plan, Outcome: TimeoutOutcome})
+	assert.NoError(t, err)
+	assert.NoError(t, store.Add(record))
+
+	reopened, err := OpenStore(filepath)
+	assert.NoError(t, err)
+	defer reopened.Close()
+	recorded, ok := reopened.Lookup("abc", plan)
+	assert.True(t, ok)
+	assert.Equal(t, TimeoutOutcome, recorded.Outcome)
+}
+
+func TestStoreInterrupted(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	// The campaign runs out of time while the plan skipping 0x1008 is executed.
+	var deadline time.Time
+	injector := NewFakeInjector(func(plan fi.AttackPlan) (pkg.VerifyPINOutput, error) {
+		if plan.String() == "[is 4104 0]" && !deadline.IsZero() {
+			time.Sleep(time.Until(deadline) + 100*time.Millisecond)
+		}
+		return fakeVerifyPIN(plan)
+	})
+	runner := NewInjectorRunner[pkg.VerifyPINInput, pkg.VerifyPINOutput](
+		injector, "github.com/hyperproperties/gorrupt/examples/fissc/VerifyPIN_0/pkg", "pkg", "",
+		"GOARCH=arm", "GOOS=linux",
+	)
+	options := []QuantifierOption{
+		WithDirectory(t.TempDir()), WithTimeout(time.Minute), WithStore(StoreName), WithLimit(-1),
+		WithTargetOptions(skips(0x1000, 0x1004, 0x1008, 0x100c)),
+	}
+	configuration := NewQuantifierConfiguration(options...)
+	assert.NoError(t, runner.Prepare(ctx, wrongPIN, &configuration))
+	holds := func(pinExecution) (bool, error) {
+		return true, nil
+	}
+
+	campaign, stop := context.WithTimeout(ctx, time.Second)
+	defer stop()
+	deadline, _ = campaign.Deadline()
+	_, err := runner.Forall(campaign, configuration, iterx.Once2(wrongPIN), holds)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	store, err := OpenStore(configuration.StorePath())
+	assert.NoError(t, err)
+	for record := range store.Records() {
+		assert.NotEqual(t, TimeoutOutcome, record.Outcome, record.Plan)
+	}
+	// The golden execution and the plans before the interruption.
+	assert.Equal(t, 3, store.Len())
+	assert.NoError(t, store.Close())
+
+	// The campaign is resumed with the remaining plans by a new configuration, e.g., of a later test run,
+	// which builds the same binary again and therefore finds its records.
+	deadline = time.Time{}
+	executions := injector.Executions()
+	resumed := NewQuantifierConfiguration(options...)
+	result, err := runner.Forall(ctx, resumed, iterx.Once2(wrongPIN), func(execution pinExecution) (bool, error) {
+		return execution.Outcome != CrashOutcome, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, executions+2, injector.Executions())
+	counterexample, ok := result.Counterexample()
+	assert.True(t, ok)
+	assert.Equal(t, "[is 4104 0]", counterexample.Plan.String())
+}
@@ -0,0 +1,25 @@
+#!/bin/sh
+# A fake emulator of the binary of VerifyPIN with a wrong PIN. It answers the handshake of "qemu.sh -version"
+# and "qemu.sh -fi help". It is called as "qemu.sh -fi ATTACK BINARY ..." and prints the encoded output for
+# the attacks in the file ATTACK without running the binary:
+#   is 4096 0    triggers the countermeasure.
+#   is 4100 0    authenticates the user.
+#   is 4104 0    crashes.
+#   bfr with bit 2 in its mask authenticates the user.
+#   otherwise    rejects the PIN.
+if [ "$1" = "-version" ]; then
+	echo "qemu-arm version 8.2.0 (qemu-fi)"
+elif [ "$2" = "help" ]; then
+	printf "Fault models:\nfi-model: bfr\nfi-model: bfm\nfi-model: sar\nfi-model: sr\nfi-model: is\nfi-model: ic\n"
+elif grep -q "^is 4096 " "$2"; then
+	echo eyJSZXQwIjpmYWxzZSwiQ291bnRlcm1lYXN1cmUiOnRydWUsIlBUQyI6Mn0=
+elif grep -q "^is 4100 " "$2"; then
+	echo eyJSZXQwIjp0cnVlLCJDb3VudGVybWVhc3VyZSI6ZmFsc2UsIlBUQyI6M30=
+elif grep -q "^is 4104 " "$2"; then
+	echo "panic: runtime error: invalid memory address or nil pointer dereference"
+	exit 2
+elif awk '$1 == "bfr" && int($6 / 4) % 2 == 1 { found = 1 } END { exit !found }' "$2"; then
+	echo eyJSZXQwIjp0cnVlLCJDb3VudGVybWVhc3VyZSI6ZmFsc2UsIlBUQyI6M30=
+else
+	echo eyJSZXQwIjpmYWxzZSwiQ291bnRlcm1lYXN1cmUiOmZhbHNlLCJQVEMiOjJ9
+fi
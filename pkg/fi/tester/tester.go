@@ -2,7 +2,6 @@ package tester
 
 import (
 	"context"
-	"errors"
 	"os"
 	"path"
 	"sync/atomic"
@@ -42,19 +41,7 @@ func E0[In, Out any](context context.Context, runner *Runner[In, Out], main stri
 }
 
 func E1[In, Out any](context context.Context, runner *Runner[In, Out], directory string, binary string, plan fi.AttackPlan) (Out, error) {
-	attack, err := runner.Configure(context, directory, plan)
-	if err != nil {
-		var zero Out
-		return zero, err
-	}
-	
-	output, err := runner.QEMU(context, binary, attack)
-
-	if rmErr := os.Remove(attack); rmErr != nil {
-		return output, errors.Join(rmErr, err)
-	}
-
-	return output, err
+	return runner.Execute(context, directory, binary, plan)
 }
 
 type InstructionsOption func(dump *obj.Dump) []obj.Instruction
@@ -103,6 +90,21 @@ func LinearSearchTargets[T fi.Target](searcher fi.LinearSearcher[T], options ...
 	}
 }
 
+// Searches the instructions of every option on its own and joins the targets, e.g., for searchers
+// constructing the CFG of a single function.
+func FunctionSearchTargets[T fi.Target](searcher fi.LinearSearcher[T], options ...InstructionsOption) TargetsOption {
+	return func(dump *obj.Dump) []fi.Target {
+		targets := make([]fi.Target, 0)
+		for _, option := range options {
+			for _, target := range searcher.Instructions(option(dump)) {
+				targets = append(targets, target)
+			}
+		}
+
+		return targets
+	}
+}
+
 func CheckParallel[In, Out any](
 	ctx context.Context,
 	runner *Runner[In, Out],
@@ -116,12 +118,17 @@ func CheckParallel[In, Out any](
 	os.MkdirAll(directory, os.ModePerm)
 	defer os.RemoveAll("./tmp/")
 
-	main, binary, landfill, err := Setup(ctx, directory, runner, input)
+	main, err := runner.Generate(ctx, directory, input)
+	if err != nil {
+		return err
+	}
+
+	binary, err := runner.Build(ctx, directory, main)
 	if err != nil {
 		return err
 	}
 
-	dump, err := obj.ParseFile(landfill)
+	dump, err := obj.LoadFile(binary)
 	if err != nil {
 		return err
 	}
@@ -108,6 +108,94 @@ func Permutations(sub, slice int) iter.Seq[[]int] {
 	}
 }
 
+// Arrangements yields every ordered selection of sub distinct indices from [0, slice).
+// Unlike Permutations no index is repeated within a yielded selection.
+func Arrangements(sub, slice int) iter.Seq[[]int] {
+	if sub < 0 {
+		panic("arrangements of negative length subslices")
+	}
+
+	if sub == 0 || sub > slice {
+		return func(yield func([]int) bool) {}
+	}
+
+	return func(yield func([]int) bool) {
+		used := make([]bool, slice)
+		arrangement := make([]int, 0, sub)
+
+		var recurse func() bool
+		recurse = func() bool {
+			if len(arrangement) == sub {
+				yielded := make([]int, sub)
+				copy(yielded, arrangement)
+				return yield(yielded)
+			}
+
+			for idx := 0; idx < slice; idx++ {
+				if used[idx] {
+					continue
+				}
+
+				used[idx] = true
+				arrangement = append(arrangement, idx)
+				if !recurse() {
+					return false
+				}
+				arrangement = arrangement[:len(arrangement)-1]
+				used[idx] = false
+			}
+
+			return true
+		}
+
+		recurse()
+	}
+}
+
+// Combinations yields every unordered selection of sub distinct indices from [0, slice).
+// The indices of each yielded selection are in increasing order.
+func Combinations(sub, slice int) iter.Seq[[]int] {
+	if sub < 0 {
+		panic("combinations of negative length subslices")
+	}
+
+	if sub == 0 || sub > slice {
+		return func(yield func([]int) bool) {}
+	}
+
+	return func(yield func([]int) bool) {
+		counters := make([]int, sub)
+		for idx := range counters {
+			counters[idx] = idx
+		}
+
+		for {
+			combination := make([]int, sub)
+			copy(combination, counters)
+			if !yield(combination) {
+				return
+			}
+
+			// Find the rightmost element that can still be incremented.
+			i := sub - 1
+			for i >= 0 && counters[i] == slice-sub+i {
+				i--
+			}
+
+			if i < 0 {
+				break
+			}
+
+			counters[i]++
+
+			// Every element to the right of i follows its left neighbour.
+			for j := i + 1; j < sub; j++ {
+				counters[j] = counters[j-1] + 1
+			}
+		}
+	}
+}
+
 func IncrementalPermutations(sub, slice, added int) iter.Seq[[]int] {
 	if added == 0 {
 		return func(yield func([]int) bool) {}
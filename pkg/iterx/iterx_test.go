@@ -69,6 +69,98 @@ func TestPermutations(t *testing.T) {
 	}
 }
 
+func TestArrangements(t *testing.T) {
+	// Number of arrangements=slice!/(slice-sub)!
+	tests := []struct {
+		description  string
+		sub, slice   int
+		arrangements int
+	}{
+		{
+			description:  "",
+			sub:          1,
+			slice:        1,
+			arrangements: 1,
+		},
+		{
+			description:  "",
+			sub:          2,
+			slice:        1,
+			arrangements: 0,
+		},
+		{
+			description:  "",
+			sub:          2,
+			slice:        3,
+			arrangements: 6,
+		},
+		{
+			description:  "",
+			sub:          3,
+			slice:        5,
+			arrangements: 60,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			arrangements := Collect(Arrangements(tt.sub, tt.slice))
+			assert.Len(t, arrangements, tt.arrangements)
+			for idx, arrangement := range arrangements {
+				assert.NotContains(t, arrangements[idx+1:], arrangement)
+				for jdx := range arrangement {
+					assert.NotContains(t, arrangement[jdx+1:], arrangement[jdx])
+				}
+			}
+		})
+	}
+}
+
+func TestCombinations(t *testing.T) {
+	// Number of combinations=slice!/(sub!(slice-sub)!)
+	tests := []struct {
+		description  string
+		sub, slice   int
+		combinations int
+	}{
+		{
+			description:  "",
+			sub:          1,
+			slice:        1,
+			combinations: 1,
+		},
+		{
+			description:  "",
+			sub:          2,
+			slice:        1,
+			combinations: 0,
+		},
+		{
+			description:  "",
+			sub:          2,
+			slice:        3,
+			combinations: 3,
+		},
+		{
+			description:  "",
+			sub:          3,
+			slice:        13,
+			combinations: 286,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			combinations := Collect(Combinations(tt.sub, tt.slice))
+			assert.Len(t, combinations, tt.combinations)
+			for idx, combination := range combinations {
+				assert.NotContains(t, combinations[idx+1:], combination)
+				assert.IsIncreasing(t, combination)
+			}
+		})
+	}
+}
+
 func TestIncrementalPermutations(t *testing.T) {
 	tests := []struct {
 		description       string
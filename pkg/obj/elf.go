@@ -0,0 +1,176 @@
+package obj
+
+import (
+	"debug/elf"
+	"debug/gosym"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"golang.org/x/arch/arm/armasm"
+)
+
+var ErrUnsupportedMachine = errors.New("unsupported machine")
+
+// Loads the dump of a Go binary by disassembling it directly.
+// The result is the same as parsing the output of "go tool objdump" on the binary.
+func LoadFile(path string) (Dump, error) {
+	file, err := elf.Open(path)
+	if err != nil {
+		var zero Dump
+		return zero, err
+	}
+	defer file.Close()
+
+	return Load(file)
+}
+
+// Loads the dump of a Go binary from its ELF file. The functions are found in the symbol table,
+// the sources in the Go line table (.gopclntab), and the instructions with the Go ARM disassembler.
+func Load(file *elf.File) (Dump, error) {
+	var zero Dump
+
+	if file.Machine != elf.EM_ARM {
+		return zero, fmt.Errorf("%w: %s", ErrUnsupportedMachine, file.Machine)
+	}
+
+	text := file.Section(".text")
+	if text == nil {
+		return zero, errors.New("missing .text section")
+	}
+	code, err := text.Data()
+	if err != nil {
+		return zero, err
+	}
+
+	pclntab := file.Section(".gopclntab")
+	if pclntab == nil {
+		return zero, errors.New("missing .gopclntab section")
+	}
+	lines, err := pclntab.Data()
+	if err != nil {
+		return zero, err
+	}
+	table, err := gosym.NewTable(nil, gosym.NewLineTable(lines, text.Addr))
+	if err != nil {
+		return zero, err
+	}
+
+	symbols, err := textSymbols(file)
+	if err != nil {
+		return zero, err
+	}
+
+	lookup := func(address uint64) (string, uint64) {
+		idx, found := slices.BinarySearchFunc(symbols, address, func(symbol elf.Symbol, address uint64) int {
+			switch {
+			case symbol.Value+symbol.Size <= address:
+				return -1
+			case symbol.Value > address:
+				return 1
+			}
+			return 0
+		})
+		if !found {
+			return "", 0
+		}
+		return symbols[idx].Name, symbols[idx].Value
+	}
+
+	reader := textReader{code, text.Addr}
+	var functions []Function
+	for _, symbol := range symbols {
+		start, end := symbol.Value, symbol.Value+symbol.Size
+		if start < text.Addr || end > text.Addr+uint64(len(code)) {
+			continue
+		}
+
+		var instructions []Instruction
+		for pc := start; pc+4 <= end; pc += 4 {
+			raw := code[pc-text.Addr : end-text.Addr]
+			opcode := binary.LittleEndian.Uint32(raw)
+
+			name := "?"
+			if inst, err := armasm.Decode(raw, armasm.ModeARM); err == nil && inst.Len != 0 && inst.Op != 0 {
+				name = armasm.GoSyntax(inst, pc, lookup, reader)
+			}
+
+			file, line, _ := table.PCToLine(pc)
+			source := fmt.Sprintf("%s:%d", base(file), line)
+			instructions = append(instructions, NewInstruction(source, pc, uint64(opcode), name))
+		}
+
+		file, _, _ := table.PCToLine(start)
+		functions = append(functions, NewFunction("TEXT", symbol.Name+"(SB)", file, instructions...))
+	}
+
+	return New(functions...), nil
+}
+
+// The symbols of the executable code sorted by their address.
+func textSymbols(file *elf.File) ([]elf.Symbol, error) {
+	symbols, err := file.Symbols()
+	if err != nil {
+		return nil, err
+	}
+
+	symbols = slices.DeleteFunc(symbols, func(symbol elf.Symbol) bool {
+		switch symbol.Name {
+		case "runtime.text", "text", "_text", "runtime.etext", "etext", "_etext":
+			return true
+		}
+
+		if symbol.Section == elf.SHN_UNDEF || int(symbol.Section) >= len(file.Sections) {
+			return true
+		}
+
+		flags := file.Sections[symbol.Section].Flags
+		return flags&(elf.SHF_WRITE|elf.SHF_ALLOC|elf.SHF_EXECINSTR) != elf.SHF_ALLOC|elf.SHF_EXECINSTR
+	})
+
+	slices.SortStableFunc(symbols, func(a, b elf.Symbol) int {
+		switch {
+		case a.Value < b.Value:
+			return -1
+		case a.Value > b.Value:
+			return 1
+		}
+		return 0
+	})
+
+	return symbols, nil
+}
+
+// The file name without its directories as printed by "go tool objdump".
+func base(path string) string {
+	path = path[strings.LastIndex(path, "/")+1:]
+	path = path[strings.LastIndex(path, `\`)+1:]
+	return path
+}
+
+// Reads the text section by addresses such that the disassembler can resolve literal pools.
+type textReader struct {
+	code []byte
+	pc   uint64
+}
+
+func (reader textReader) ReadAt(data []byte, offset int64) (n int, err error) {
+	if offset < 0 || uint64(offset) < reader.pc {
+		return 0, io.EOF
+	}
+
+	delta := uint64(offset) - reader.pc
+	if delta >= uint64(len(reader.code)) {
+		return 0, io.EOF
+	}
+
+	n = copy(data, reader.code[delta:])
+	if n < len(data) {
+		err = io.ErrUnexpectedEOF
+	}
+
+	return
+}
@@ -0,0 +1,57 @@
+package obj
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadFileMatchesObjdump(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds a binary")
+	}
+
+	binary := path.Join(t.TempDir(), "binary")
+	build := exec.Command("go", "build", "-o", binary, "../../examples/fissc/binaries/VerifyPIN_0_1/1-main.go")
+	build.Env = append(os.Environ(), "GOARCH=arm", "GOOS=linux")
+	output, err := build.CombinedOutput()
+	require.NoError(t, err, string(output))
+
+	var objdump bytes.Buffer
+	command := exec.Command("go", "tool", "objdump", binary)
+	command.Stdout = &objdump
+	require.NoError(t, command.Run())
+
+	expected, err := Parse(&objdump)
+	require.NoError(t, err)
+
+	actual, err := LoadFile(binary)
+	require.NoError(t, err)
+
+	pkg := "github.com/hyperproperties/gorrupt/examples/fissc/VerifyPIN_0/pkg"
+	for _, name := range []string{"VerifyPIN", "PINCompare", "VerifyPINInput.Call"} {
+		t.Run(name, func(t *testing.T) {
+			expected := expected.FunctionInPackage(pkg, name)
+			actual := actual.FunctionInPackage(pkg, name)
+			require.Len(t, actual, len(expected))
+			for i := range expected {
+				assert.Equal(t, expected[i], actual[i])
+			}
+		})
+	}
+
+	assert.Equal(t, expected.Size(), actual.Size())
+}
+
+func TestLoadFileUnsupported(t *testing.T) {
+	executable, err := os.Executable()
+	require.NoError(t, err)
+
+	_, err = LoadFile(executable)
+	assert.ErrorIs(t, err, ErrUnsupportedMachine)
+}
@@ -226,6 +226,23 @@ func (objdump Dump) FunctionInPackageWithReceiver(pack, receiver, name string) [
 	})
 }
 
+// Finds the function and instruction at the offset.
+func (objdump Dump) Instruction(offset uint64) (Function, Instruction, bool) {
+	for _, function := range objdump.functions {
+		if offset < function.Start() || offset >= function.End() {
+			continue
+		}
+
+		for _, instruction := range function.instructions {
+			if instruction.Offset() == offset {
+				return function, instruction, true
+			}
+		}
+	}
+
+	return Function{}, Instruction{}, false
+}
+
 func splitN(s, sep string, n int) (splitN []string) {
 	parts := strings.Split(s, sep)
 
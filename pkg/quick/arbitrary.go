@@ -0,0 +1,49 @@
+package quick
+
+import "reflect"
+
+var arbitraryType = reflect.TypeFor[Arbitrary]()
+
+// A type which generates its own random values, e.g., to only generate valid values. The method is called
+// on the zero value of the type (or a pointer to it) and must return a value of the type like testing/quick.
+type Arbitrary interface {
+	Generate(generator *Generator) reflect.Value
+}
+
+// Registers the function generating the values of the type with the generator. A registered function takes
+// precedence over an Arbitrary implementation and the reflection of the kind of the type.
+func Register[T any](generator *Generator, function func(generator *Generator) T) {
+	if generator.functions == nil {
+		generator.functions = make(map[reflect.Type]func(generator *Generator) reflect.Value)
+	}
+	generator.functions[reflect.TypeFor[T]()] = func(generator *Generator) reflect.Value {
+		value := function(generator)
+		return reflect.ValueOf(&value).Elem()
+	}
+}
+
+// Sets the value using a registered function or its Arbitrary implementation and reports if it did.
+func (generator *Generator) arbitrary(value reflect.Value) bool {
+	if function, exists := generator.functions[value.Type()]; exists {
+		value.Set(function(generator))
+		return true
+	}
+
+	switch value.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		// A nil interface or pointer cannot be called and pointers are generated through what they point to.
+		return false
+	}
+
+	if value.Type().Implements(arbitraryType) {
+		value.Set(reflect.Zero(value.Type()).Interface().(Arbitrary).Generate(generator))
+		return true
+	}
+
+	if reflect.PointerTo(value.Type()).Implements(arbitraryType) {
+		value.Set(reflect.New(value.Type()).Interface().(Arbitrary).Generate(generator))
+		return true
+	}
+
+	return false
+}
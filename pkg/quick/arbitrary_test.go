@@ -0,0 +1,61 @@
+package quick
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type even int
+
+func (even) Generate(generator *Generator) reflect.Value {
+	return reflect.ValueOf(even(2 * generator.rand.IntN(50)))
+}
+
+type point struct {
+	X, Y int
+}
+
+func (*point) Generate(generator *Generator) reflect.Value {
+	x := generator.rand.IntN(10)
+	return reflect.ValueOf(point{x, x})
+}
+
+type shape interface {
+	Area() int
+}
+
+type square int
+
+func (square square) Area() int {
+	return int(square * square)
+}
+
+func TestArbitrary(t *testing.T) {
+	type value struct {
+		Even   even
+		Point  *point
+		Shape  shape
+		Number int
+	}
+
+	generator := NewGenerator(3)
+	Register(generator, func(generator *Generator) shape {
+		return square(1 + generator.rand.IntN(3))
+	})
+	Register(generator, func(*Generator) int {
+		return 42
+	})
+
+	for range 100 {
+		value := Generate[value](generator)
+		assert.Equal(t, 0, int(value.Even)%2)
+		assert.Equal(t, value.Point.X, value.Point.Y)
+		assert.Contains(t, []int{1, 4, 9}, value.Shape.Area())
+		assert.Equal(t, 42, value.Number)
+	}
+
+	// Interfaces without a registered function are left as is.
+	assert.Nil(t, Generate[shape](NewGenerator(3)))
+}
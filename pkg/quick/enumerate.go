@@ -0,0 +1,260 @@
+package quick
+
+import (
+	"fmt"
+	"iter"
+	"math"
+	"math/bits"
+	"reflect"
+	"unsafe"
+)
+
+// The finite domain of a type whose values are indexed from 0 to size-1.
+type domain struct {
+	size int
+	// Sets the value to the value at the index of the domain.
+	set func(value reflect.Value, index int)
+}
+
+// Enumerates every value of the type up to the depth in the style of SmallCheck. The depth bounds the numbers
+// to [-depth, depth], the lengths of slices and strings to [0, depth], and the alphabet of strings to its first
+// depth letters unless the struct tags of the fields give other domains as hints, see Tag. Booleans are false
+// and true, pointers point to the values of their element, and maps and interfaces are empty. The values are
+// ordered by their index in the domain where the first element (or field) varies the fastest.
+// It panics if a tag is invalid or the domain has more values than an int can index.
+func Enumerate[T any](depth int) iter.Seq2[int, T] {
+	domain := newDomain(reflect.TypeFor[T](), defaultHints, depth)
+	return func(yield func(int, T) bool) {
+		for index := range domain.size {
+			var value T
+			domain.set(reflect.ValueOf(&value).Elem(), index)
+			if !yield(index, value) {
+				return
+			}
+		}
+	}
+}
+
+// The number of values Enumerate yields for the type and depth.
+func Count[T any](depth int) int {
+	return newDomain(reflect.TypeFor[T](), defaultHints, depth).size
+}
+
+func newDomain(typ reflect.Type, hints hints, depth int) domain {
+	if len(hints.enum) > 0 {
+		switch typ.Kind() {
+		case reflect.Array, reflect.Slice, reflect.Map, reflect.Ptr, reflect.Struct, reflect.Interface:
+		default:
+			for _, str := range hints.enum {
+				if err := setString(reflect.New(typ).Elem(), str); err != nil {
+					panic(fmt.Errorf("quick: invalid enumeration of %s: %w", typ, err))
+				}
+			}
+			return domain{
+				size: len(hints.enum),
+				set: func(value reflect.Value, index int) {
+					setString(value, hints.enum[index])
+				},
+			}
+		}
+	}
+
+	switch kind := typ.Kind(); kind {
+	case reflect.Bool:
+		return domain{
+			size: 2,
+			set: func(value reflect.Value, index int) {
+				value.SetBool(index == 1)
+			},
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		lower, upper, err := hints.bounded(typ, depth).ints(typ)
+		if err != nil {
+			panic(fmt.Errorf("quick: invalid range of %s: %w", typ, err))
+		}
+		return domain{
+			size: span(uint64(upper-lower), typ),
+			set: func(value reflect.Value, index int) {
+				value.SetInt(lower + int64(index))
+			},
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		lower, upper, err := hints.bounded(typ, depth).uints(typ)
+		if err != nil {
+			panic(fmt.Errorf("quick: invalid range of %s: %w", typ, err))
+		}
+		return domain{
+			size: span(upper-lower, typ),
+			set: func(value reflect.Value, index int) {
+				value.SetUint(lower + uint64(index))
+			},
+		}
+	case reflect.Float32, reflect.Float64:
+		// Only the whole numbers of the range are enumerated.
+		lower, upper, err := hints.bounded(typ, depth).floats(typ)
+		if err != nil {
+			panic(fmt.Errorf("quick: invalid range of %s: %w", typ, err))
+		}
+		lower, upper = math.Ceil(lower), math.Floor(upper)
+		if upper < lower || upper-lower >= math.MaxInt {
+			panic(fmt.Errorf("quick: the whole numbers of %s in %g..%g cannot be enumerated", typ, lower, upper))
+		}
+		return domain{
+			size: int(upper-lower) + 1,
+			set: func(value reflect.Value, index int) {
+				value.SetFloat(lower + float64(index))
+			},
+		}
+	case reflect.Complex64, reflect.Complex128:
+		// Only the real whole numbers are enumerated.
+		return domain{
+			size: 2*depth + 1,
+			set: func(value reflect.Value, index int) {
+				value.SetComplex(complex(float64(index-depth), 0))
+			},
+		}
+	case reflect.String:
+		alphabet := USAlphabet[:min(depth, len(USAlphabet))]
+		letter := domain{
+			size: len(alphabet),
+			set: func(value reflect.Value, index int) {
+				value.Set(reflect.ValueOf(alphabet[index]))
+			},
+		}
+		runes := sequences(reflect.TypeFor[[]rune](), letter, hints.bounded(typ, depth))
+		return domain{
+			size: runes.size,
+			set: func(value reflect.Value, index int) {
+				str := reflect.New(reflect.TypeFor[[]rune]()).Elem()
+				runes.set(str, index)
+				value.SetString(string(str.Interface().([]rune)))
+			},
+		}
+	case reflect.Slice:
+		return sequences(typ, newDomain(typ.Elem(), hints.elements(), depth), hints.bounded(typ, depth))
+	case reflect.Array:
+		element := newDomain(typ.Elem(), hints.elements(), depth)
+		elements := make([]domain, typ.Len())
+		for i := range elements {
+			elements[i] = element
+		}
+		return product(typ, elements, func(value reflect.Value, i int) reflect.Value {
+			return value.Index(i)
+		})
+	case reflect.Struct:
+		fields := make([]domain, typ.NumField())
+		for i := range fields {
+			hints, err := parseHints(typ.Field(i).Tag.Get(Tag))
+			if err != nil {
+				panic(fmt.Errorf("quick: invalid tag of %s.%s: %w", typ, typ.Field(i).Name, err))
+			}
+			fields[i] = newDomain(typ.Field(i).Type, hints, depth)
+		}
+		return product(typ, fields, func(value reflect.Value, i int) reflect.Value {
+			field := value.Field(i)
+			if field.CanSet() {
+				return field
+			}
+			return reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem()
+		})
+	case reflect.Ptr:
+		element := newDomain(typ.Elem(), hints, depth)
+		return domain{
+			size: element.size,
+			set: func(value reflect.Value, index int) {
+				pointer := reflect.New(typ.Elem())
+				element.set(pointer.Elem(), index)
+				value.Set(pointer)
+			},
+		}
+	case reflect.Map:
+		return domain{
+			size: 1,
+			set: func(value reflect.Value, index int) {
+				value.Set(reflect.MakeMap(typ))
+			},
+		}
+	default:
+		// The zero value is the only value of interfaces, channels, functions, and unsafe pointers.
+		return domain{
+			size: 1,
+			set:  func(reflect.Value, int) {},
+		}
+	}
+}
+
+// The number of values in a range of the distance from the lower to the upper bound.
+func span(distance uint64, typ reflect.Type) int {
+	if distance >= math.MaxInt {
+		panic(fmt.Errorf("quick: the domain of %s is too large to enumerate", typ))
+	}
+	return int(distance) + 1
+}
+
+// The domain of the cartesian product of the domains of the components of the type.
+func product(typ reflect.Type, components []domain, component func(value reflect.Value, i int) reflect.Value) domain {
+	size := 1
+	for _, domain := range components {
+		size = multiply(size, domain.size, typ)
+	}
+
+	return domain{
+		size: size,
+		set: func(value reflect.Value, index int) {
+			for i, domain := range components {
+				domain.set(component(value, i), index%domain.size)
+				index /= domain.size
+			}
+		},
+	}
+}
+
+// The domain of the slices of the type whose lengths are within the hints and whose elements are in the domain.
+// The slices are ordered by their length.
+func sequences(typ reflect.Type, element domain, hints hints) domain {
+	sizes := make([]int, 0, hints.maxLength-hints.minLength+1)
+	size, count := 0, 1
+	for length := range hints.maxLength + 1 {
+		if length >= hints.minLength {
+			sizes = append(sizes, count)
+			size = add(size, count, typ)
+		}
+		count = multiply(count, element.size, typ)
+	}
+
+	return domain{
+		size: size,
+		set: func(value reflect.Value, index int) {
+			length := hints.minLength
+			for _, size := range sizes {
+				if index < size {
+					break
+				}
+				index -= size
+				length++
+			}
+
+			slice := reflect.MakeSlice(typ, length, length)
+			for i := range length {
+				element.set(slice.Index(i), index%element.size)
+				index /= element.size
+			}
+			value.Set(slice)
+		},
+	}
+}
+
+func multiply(x, y int, typ reflect.Type) int {
+	hi, lo := bits.Mul64(uint64(x), uint64(y))
+	if hi != 0 || lo > math.MaxInt {
+		panic(fmt.Errorf("quick: the domain of %s is too large to enumerate", typ))
+	}
+	return int(lo)
+}
+
+func add(x, y int, typ reflect.Type) int {
+	if x > math.MaxInt-y {
+		panic(fmt.Errorf("quick: the domain of %s is too large to enumerate", typ))
+	}
+	return x + y
+}
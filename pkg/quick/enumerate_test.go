@@ -0,0 +1,81 @@
+package quick
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func collect[T any](depth int) []T {
+	values := make([]T, 0)
+	for i, value := range Enumerate[T](depth) {
+		if i != len(values) {
+			panic("unexpected index")
+		}
+		values = append(values, value)
+	}
+	return values
+}
+
+func TestEnumerate(t *testing.T) {
+	type pin struct {
+		UserPIN [2]byte `quick:"min=0,max=9"`
+	}
+	type tagged struct {
+		Mode    string `quick:"enum=read|write"`
+		Retries *uint8 `quick:"max=1"`
+		Flags   []bool `quick:"len=1"`
+		hidden  int8   `quick:"min=-1,max=0"`
+	}
+
+	assert.Equal(t, []bool{false, true}, collect[bool](3))
+	assert.Equal(t, []int8{-2, -1, 0, 1, 2}, collect[int8](2))
+	assert.Equal(t, []uint16{0, 1, 2}, collect[uint16](2))
+	assert.Equal(t, []float32{-1, 0, 1}, collect[float32](1))
+	assert.Equal(t, []string{"", "a", "b", "aa", "ba", "ab", "bb"}, collect[string](2))
+	assert.Equal(t, [][]bool{{}, {false}, {true}}, collect[[]bool](1))
+	assert.Equal(t, [][2]bool{{false, false}, {true, false}, {false, true}, {true, true}}, collect[[2]bool](0))
+	assert.Equal(t, []int8{-128, 0, 127}, []int8{collect[int8](1000)[0], collect[int8](1000)[128], collect[int8](1000)[255]})
+	assert.Equal(t, []map[int]int{{}}, collect[map[int]int](2))
+
+	pins := collect[pin](5)
+	assert.Len(t, pins, 100)
+	assert.Equal(t, pin{[2]byte{0, 0}}, pins[0])
+	assert.Equal(t, pin{[2]byte{3, 7}}, pins[73])
+	assert.Equal(t, pin{[2]byte{9, 9}}, pins[99])
+
+	values := collect[tagged](5)
+	assert.Equal(t, 2*2*2*2, Count[tagged](5))
+	assert.Len(t, values, 16)
+	assert.Equal(t, tagged{Mode: "read", Retries: new(uint8), Flags: []bool{false}, hidden: -1}, values[0])
+	assert.Equal(t, "write", values[1].Mode)
+	assert.Equal(t, uint8(1), *values[2].Retries)
+	assert.NotSame(t, values[0].Retries, values[1].Retries)
+	assert.Equal(t, []bool{true}, values[4].Flags)
+	assert.Equal(t, int8(0), values[8].hidden)
+
+	// The enumeration stops early.
+	assert.Equal(t, []int{-3, -2}, slices.Collect(func(yield func(int) bool) {
+		for i, value := range Enumerate[int](3) {
+			if i == 2 || !yield(value) {
+				return
+			}
+		}
+	}))
+}
+
+func TestEnumerateTooLarge(t *testing.T) {
+	assert.Panics(t, func() { Count[[64]bool](0) })
+	assert.Panics(t, func() { Count[[]int](1000) })
+	assert.Panics(t, func() {
+		Count[struct {
+			Value uint64 `quick:"max=0xffffffffffffffff"`
+		}](0)
+	})
+	assert.Panics(t, func() {
+		Count[struct {
+			Value float64 `quick:"max=1e300"`
+		}](1)
+	})
+}
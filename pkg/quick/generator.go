@@ -0,0 +1,81 @@
+package quick
+
+import (
+	"iter"
+	"math/rand/v2"
+	"reflect"
+)
+
+// The generator of the package level functions which uses the global source of math/rand/v2.
+var global = &Generator{
+	rand: rand.New(globalSource{}),
+}
+
+type globalSource struct{}
+
+func (globalSource) Uint64() uint64 {
+	return rand.Uint64()
+}
+
+// A generator of random values with its own pseudo-random source. Generators with the same seed generate the
+// same values in the same order, so a campaign is reproduced by its seed. A generator is not safe for concurrent use.
+type Generator struct {
+	seed      uint64
+	rand      *rand.Rand
+	functions map[reflect.Type]func(generator *Generator) reflect.Value
+}
+
+func NewGenerator(seed uint64) *Generator {
+	return &Generator{
+		seed: seed,
+		rand: rand.New(rand.NewPCG(seed, seed)),
+	}
+}
+
+// A generator with a random seed which should be logged to reproduce it.
+func NewRandomGenerator() *Generator {
+	return NewGenerator(rand.Uint64())
+}
+
+func (generator *Generator) Seed() uint64 {
+	return generator.seed
+}
+
+// Sets the value pointed to to a new random value.
+func (generator *Generator) Update(value any) {
+	generator.Reflect(reflect.ValueOf(value).Elem())
+}
+
+func (generator *Generator) String(length int, alphabet ...rune) string {
+	buffer := make([]rune, length)
+	for i := range buffer {
+		buffer[i] = alphabet[generator.rand.IntN(len(alphabet))]
+	}
+	return string(buffer)
+}
+
+// A uniformly random number in [0, n) where n=0 is the full range of 2^64 numbers.
+func (generator *Generator) uint64N(n uint64) uint64 {
+	if n == 0 {
+		return generator.rand.Uint64()
+	}
+	return generator.rand.Uint64N(n)
+}
+
+// A new random value from the generator.
+func Generate[T any](generator *Generator) T {
+	var value T
+	generator.Reflect(reflect.ValueOf(&value).Elem())
+	return value
+}
+
+// The infinite sequence of random values from the generator.
+func Generated[T any](generator *Generator) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := 0; ; i++ {
+			if !yield(i, Generate[T](generator)) {
+				return
+			}
+		}
+	}
+}
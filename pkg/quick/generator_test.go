@@ -0,0 +1,45 @@
+package quick
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerator(t *testing.T) {
+	type value struct {
+		Number int
+		Bytes  [4]byte
+		Values []uint16
+		hidden *bool
+	}
+
+	generate := func(seed uint64) ([]value, string) {
+		generator := NewGenerator(seed)
+		values := make([]value, 0)
+		for _, value := range Generated[value](generator) {
+			values = append(values, value)
+			if len(values) == 10 {
+				break
+			}
+		}
+		return values, generator.String(32, USAlphabet...)
+	}
+
+	values, str := generate(42)
+	again, strAgain := generate(42)
+	other, strOther := generate(43)
+
+	assert.Equal(t, values, again)
+	assert.Equal(t, str, strAgain)
+	assert.NotEqual(t, values, other)
+	assert.NotEqual(t, str, strOther)
+	assert.Equal(t, uint64(42), NewGenerator(42).Seed())
+
+	var updated, updatedAgain value
+	NewGenerator(7).Update(&updated)
+	NewGenerator(7).Update(&updatedAgain)
+	assert.Equal(t, updated, updatedAgain)
+	assert.True(t, slices.Equal(updated.Values, updatedAgain.Values))
+}
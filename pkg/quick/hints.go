@@ -0,0 +1,213 @@
+package quick
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// The key of the struct tags with hints for generating the field, e.g., `quick:"len=4,min=0,max=9"`.
+const Tag = "quick"
+
+// The hints of a struct tag which is a comma separated list of:
+//   - len=N or len=MIN..MAX the length of slices, maps and strings (0..9 by default),
+//   - min=X and max=Y the inclusive range of numbers, and
+//   - enum=A|B|C the only values of numbers, booleans and strings.
+//
+// The range and enumeration also apply to the elements of arrays, slices, maps and pointers.
+type hints struct {
+	minLength, maxLength int
+	min, max             string
+	enum                 []string
+}
+
+var defaultHints = hints{
+	minLength: 0,
+	maxLength: 9,
+}
+
+func parseHints(tag string) (hints, error) {
+	hints := defaultHints
+	if tag == "" {
+		return hints, nil
+	}
+
+	for _, hint := range strings.Split(tag, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(hint), "=")
+		if !ok {
+			return hints, fmt.Errorf("hint %q is not of the form key=value", hint)
+		}
+
+		switch key {
+		case "len":
+			lower, upper, isRange := strings.Cut(value, "..")
+			if !isRange {
+				upper = lower
+			}
+			var err error
+			if hints.minLength, err = strconv.Atoi(lower); err != nil {
+				return hints, err
+			}
+			if hints.maxLength, err = strconv.Atoi(upper); err != nil {
+				return hints, err
+			}
+			if hints.minLength < 0 || hints.maxLength < hints.minLength {
+				return hints, fmt.Errorf("invalid length %q", value)
+			}
+		case "min":
+			hints.min = value
+		case "max":
+			hints.max = value
+		case "enum":
+			hints.enum = strings.Split(value, "|")
+		default:
+			return hints, fmt.Errorf("unknown hint %q", key)
+		}
+	}
+
+	return hints, nil
+}
+
+// The hints of the elements of a container which have the default length.
+func (hints hints) elements() hints {
+	hints.minLength, hints.maxLength = defaultHints.minLength, defaultHints.maxLength
+	return hints
+}
+
+// The hints of the runes of a string of the same length whose runes are at least the first letter of USAlphabet.
+func (hints hints) letters() hints {
+	hints.min, hints.max, hints.enum = fmt.Sprint(USAlphabet[0]), "", nil
+	return hints
+}
+
+func (hints hints) length(generator *Generator) int {
+	return hints.minLength + generator.rand.IntN(hints.maxLength-hints.minLength+1)
+}
+
+func (hints hints) ranged() bool {
+	return hints.min != "" || hints.max != ""
+}
+
+// The range of the signed integer type.
+func (hints hints) ints(typ reflect.Type) (lower, upper int64, err error) {
+	lower, upper = -1<<(typ.Bits()-1), 1<<(typ.Bits()-1)-1
+	if hints.min != "" {
+		if lower, err = strconv.ParseInt(hints.min, 0, typ.Bits()); err != nil {
+			return
+		}
+	}
+	if hints.max != "" {
+		if upper, err = strconv.ParseInt(hints.max, 0, typ.Bits()); err != nil {
+			return
+		}
+	}
+	if upper < lower {
+		err = fmt.Errorf("empty range %d..%d", lower, upper)
+	}
+	return
+}
+
+// The range of the unsigned integer type.
+func (hints hints) uints(typ reflect.Type) (lower, upper uint64, err error) {
+	lower, upper = 0, math.MaxUint64>>(64-typ.Bits())
+	if hints.min != "" {
+		if lower, err = strconv.ParseUint(hints.min, 0, typ.Bits()); err != nil {
+			return
+		}
+	}
+	if hints.max != "" {
+		if upper, err = strconv.ParseUint(hints.max, 0, typ.Bits()); err != nil {
+			return
+		}
+	}
+	if upper < lower {
+		err = fmt.Errorf("empty range %d..%d", lower, upper)
+	}
+	return
+}
+
+// The range of the floating-point type.
+func (hints hints) floats(typ reflect.Type) (lower, upper float64, err error) {
+	upper = math.MaxFloat64
+	if typ.Bits() == 32 {
+		upper = math.MaxFloat32
+	}
+	lower = -upper
+	if hints.min != "" {
+		if lower, err = strconv.ParseFloat(hints.min, typ.Bits()); err != nil {
+			return
+		}
+	}
+	if hints.max != "" {
+		if upper, err = strconv.ParseFloat(hints.max, typ.Bits()); err != nil {
+			return
+		}
+	}
+	if upper < lower {
+		err = fmt.Errorf("empty range %g..%g", lower, upper)
+	}
+	return
+}
+
+// Sets the value to the textual representation of a value of its kind.
+func setString(value reflect.Value, str string) error {
+	switch value.Kind() {
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(str)
+		if err != nil {
+			return err
+		}
+		value.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(str, 0, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		parsed, err := strconv.ParseUint(str, 0, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(str, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetFloat(parsed)
+	case reflect.String:
+		value.SetString(str)
+	default:
+		return fmt.Errorf("enumeration of %s", value.Kind())
+	}
+	return nil
+}
+
+// The hints where the ranges of numbers and lengths which are not given are bounded by the depth,
+// i.e., numbers are in [-depth, depth] (within the range of the type) and lengths in [0, depth].
+func (hints hints) bounded(typ reflect.Type, depth int) hints {
+	lower, upper := -int64(depth), int64(depth)
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		lower, upper = max(lower, -1<<(typ.Bits()-1)), min(upper, 1<<(typ.Bits()-1)-1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		lower = 0
+		if typ.Bits() < 64 {
+			upper = min(upper, 1<<typ.Bits()-1)
+		}
+	}
+
+	if hints.min == "" {
+		hints.min = fmt.Sprint(lower)
+	}
+	if hints.max == "" {
+		hints.max = fmt.Sprint(upper)
+	}
+	if hints.minLength == defaultHints.minLength && hints.maxLength == defaultHints.maxLength {
+		hints.maxLength = depth
+	}
+	return hints
+}
@@ -0,0 +1,112 @@
+package quick
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHints(t *testing.T) {
+	type hinted struct {
+		PIN      [4]byte          `quick:"min=0,max=9"`
+		Digits   []int8           `quick:"len=2..5,min=-3,max=3"`
+		Name     string           `quick:"len=6"`
+		Mode     string           `quick:"enum=read|write"`
+		Attempts *uint            `quick:"enum=1|2|3"`
+		Ratio    float32          `quick:"min=0.5,max=1"`
+		Counts   map[string]int64 `quick:"len=3,min=10"`
+		Plain    []bool
+		Float    float64
+		hidden   uint16 `quick:"max=0x10"`
+	}
+
+	generator := NewGenerator(1)
+	for range 100 {
+		value := Generate[hinted](generator)
+
+		for _, digit := range value.PIN {
+			assert.LessOrEqual(t, digit, byte(9))
+		}
+		assert.GreaterOrEqual(t, len(value.Digits), 2)
+		assert.LessOrEqual(t, len(value.Digits), 5)
+		for _, digit := range value.Digits {
+			assert.GreaterOrEqual(t, digit, int8(-3))
+			assert.LessOrEqual(t, digit, int8(3))
+		}
+		assert.Len(t, value.Name, 6)
+		assert.Contains(t, []string{"read", "write"}, value.Mode)
+		assert.Contains(t, []uint{1, 2, 3}, *value.Attempts)
+		assert.GreaterOrEqual(t, value.Ratio, float32(0.5))
+		assert.LessOrEqual(t, value.Ratio, float32(1))
+		assert.LessOrEqual(t, len(value.Counts), 3)
+		for key, count := range value.Counts {
+			assert.LessOrEqual(t, len(key), 9)
+			assert.GreaterOrEqual(t, count, int64(10))
+		}
+		assert.LessOrEqual(t, len(value.Plain), 9)
+		assert.InDelta(t, 0, value.Float, floatBound)
+		assert.LessOrEqual(t, value.hidden, uint16(0x10))
+	}
+}
+
+func TestInvalidHints(t *testing.T) {
+	tests := []struct {
+		description string
+		generate    func()
+	}{
+		{
+			description: "malformed hint",
+			generate: func() {
+				Generate[struct {
+					Value int `quick:"min"`
+				}](NewGenerator(0))
+			},
+		},
+		{
+			description: "unknown hint",
+			generate: func() {
+				Generate[struct {
+					Value int `quick:"size=3"`
+				}](NewGenerator(0))
+			},
+		},
+		{
+			description: "empty range",
+			generate: func() {
+				Generate[struct {
+					Value uint8 `quick:"min=9,max=0"`
+				}](NewGenerator(0))
+			},
+		},
+		{
+			description: "out of range",
+			generate: func() {
+				Generate[struct {
+					Value int8 `quick:"max=1000"`
+				}](NewGenerator(0))
+			},
+		},
+		{
+			description: "invalid enumeration",
+			generate: func() {
+				Generate[struct {
+					Value bool `quick:"enum=yes|no"`
+				}](NewGenerator(0))
+			},
+		},
+		{
+			description: "invalid length",
+			generate: func() {
+				Generate[struct {
+					Value []int `quick:"len=5..2"`
+				}](NewGenerator(0))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			assert.Panics(t, tt.generate)
+		})
+	}
+}
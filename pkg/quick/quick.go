@@ -1,9 +1,8 @@
 package quick
 
 import (
+	"fmt"
 	"iter"
-	"math"
-	"math/rand/v2"
 	"reflect"
 	"unsafe"
 )
@@ -43,59 +42,123 @@ func Update[T any](value *T) {
 }
 
 func NewReflect(value reflect.Value) {
-	switch kind := value.Kind(); kind {
+	global.Reflect(value)
+}
+
+// Sets the value to a new random value of its kind. Registered functions and Arbitrary implementations take
+// precedence and the fields of structs are generated according to the hints of their tags. Interfaces are
+// only generated by registered functions and are left as is (e.g., nil) otherwise. It panics if a tag is invalid.
+func (generator *Generator) Reflect(value reflect.Value) {
+	generator.reflect(value, defaultHints)
+}
+
+// The bound of the floats without a range since most arithmetic on floats near the extremes of their type overflows.
+const floatBound = 1 << 20
+
+func (generator *Generator) reflect(value reflect.Value, hints hints) {
+	if generator.arbitrary(value) {
+		return
+	}
+
+	if len(hints.enum) > 0 {
+		switch value.Kind() {
+		case reflect.Array, reflect.Slice, reflect.Map, reflect.Ptr, reflect.Struct, reflect.Interface:
+		default:
+			if err := setString(value, hints.enum[generator.rand.IntN(len(hints.enum))]); err != nil {
+				panic(fmt.Errorf("quick: invalid enumeration of %s: %w", value.Type(), err))
+			}
+			return
+		}
+	}
+
+	switch value.Kind() {
 	case reflect.Bool:
-		value.SetBool(rand.Int()&1 == 0)
-	case reflect.Float32:
-		value.SetFloat(float64((rand.Float32()*2 - 1) * math.MaxFloat32))
-	case reflect.Float64:
-		value.SetFloat((rand.Float64()*2 - 1) * math.MaxFloat64)
+		value.SetBool(generator.rand.Int()&1 == 0)
+	case reflect.Float32, reflect.Float64:
+		if !hints.ranged() {
+			hints = hints.bounded(value.Type(), floatBound)
+		}
+		lower, upper, err := hints.floats(value.Type())
+		if err != nil {
+			panic(fmt.Errorf("quick: invalid range of %s: %w", value.Type(), err))
+		}
+		value.SetFloat(lower + generator.rand.Float64()*(upper-lower))
 	case reflect.Complex64:
-		value.SetComplex(complex(float64(rand.Float32()), float64(rand.Float32())))
+		value.SetComplex(complex(float64(generator.rand.Float32()), float64(generator.rand.Float32())))
 	case reflect.Complex128:
-		value.SetComplex(complex(rand.Float64(), rand.Float64()))
+		value.SetComplex(complex(generator.rand.Float64(), generator.rand.Float64()))
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		value.SetInt(rand.Int64())
+		if hints.ranged() {
+			lower, upper, err := hints.ints(value.Type())
+			if err != nil {
+				panic(fmt.Errorf("quick: invalid range of %s: %w", value.Type(), err))
+			}
+			value.SetInt(lower + int64(generator.uint64N(uint64(upper-lower)+1)))
+		} else {
+			value.SetInt(generator.rand.Int64())
+		}
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
-		value.SetUint(rand.Uint64())
+		if hints.ranged() {
+			lower, upper, err := hints.uints(value.Type())
+			if err != nil {
+				panic(fmt.Errorf("quick: invalid range of %s: %w", value.Type(), err))
+			}
+			value.SetUint(lower + generator.uint64N(upper-lower+1))
+		} else {
+			value.SetUint(generator.rand.Uint64())
+		}
+	case reflect.String:
+		value.SetString(generator.String(hints.length(generator), USAlphabet...))
 	case reflect.Slice:
-		length := int(rand.Int32N(10)) // FIXME: Hints for lengths.
+		length := hints.length(generator)
 		newSlice := reflect.MakeSlice(value.Type(), length, length)
 		for i := 0; i < length; i++ {
-			NewReflect(newSlice.Index(i))
+			generator.reflect(newSlice.Index(i), hints.elements())
 		}
 		value.Set(newSlice)
 	case reflect.Array:
 		length := value.Len()
 		for i := 0; i < length; i++ {
-			NewReflect(value.Index(i))
+			generator.reflect(value.Index(i), hints.elements())
 		}
+	case reflect.Map:
+		length := hints.length(generator)
+		newMap := reflect.MakeMapWithSize(value.Type(), length)
+		for i := 0; i < length; i++ {
+			key := reflect.New(value.Type().Key()).Elem()
+			generator.reflect(key, hints.elements())
+			element := reflect.New(value.Type().Elem()).Elem()
+			generator.reflect(element, hints.elements())
+			newMap.SetMapIndex(key, element)
+		}
+		value.Set(newMap)
 	case reflect.Struct:
 		n := value.NumField()
 		for i := 0; i < n; i++ {
+			hints, err := parseHints(value.Type().Field(i).Tag.Get(Tag))
+			if err != nil {
+				panic(fmt.Errorf("quick: invalid tag of %s.%s: %w", value.Type(), value.Type().Field(i).Name, err))
+			}
+
 			field := value.Field(i)
 			if field.CanSet() {
-				NewReflect(field)
+				generator.reflect(field, hints)
 			} else {
 				fieldPtr := unsafe.Pointer(field.UnsafeAddr())
 				unsafeField := reflect.NewAt(field.Type(), fieldPtr).Elem()
-				NewReflect(unsafeField)
+				generator.reflect(unsafeField, hints)
 			}
 		}
 	case reflect.Ptr:
 		if value.IsNil() {
 			value.Set(reflect.New(value.Type().Elem()))
 		}
-		NewReflect(value.Elem())
+		generator.reflect(value.Elem(), hints)
 	}
 }
 
 var USAlphabet = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
 
 func String(length int, alphabet ...rune) string {
-	buffer := make([]rune, length)
-    for i := range buffer {
-        buffer[i] = alphabet[rand.IntN(len(alphabet))]
-    }
-    return string(buffer)
-}
\ No newline at end of file
+	return global.String(length, alphabet...)
+}
@@ -0,0 +1,292 @@
+package quick
+
+import (
+	"fmt"
+	"iter"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+	"unsafe"
+)
+
+// Shrinks the failing value toward smaller values of the same type. It greedily moves to the first smaller
+// candidate which still fails and terminates when no candidate of the current value fails.
+func Shrink[T any](value T, fails func(value T) (bool, error)) (T, error) {
+	current := reflect.ValueOf(&value).Elem()
+	for shrunk := true; shrunk; {
+		shrunk = false
+		for candidate := range ShrinkReflect(current) {
+			ok, err := fails(candidate.Interface().(T))
+			if err != nil {
+				return current.Interface().(T), err
+			}
+			if ok {
+				current, shrunk = candidate, true
+				break
+			}
+		}
+	}
+
+	return current.Interface().(T), nil
+}
+
+// The candidates one step smaller than the value ordered from the smallest. The value is not modified and
+// every candidate is a new value of the same type. The same kinds as NewReflect are shrunk where:
+//   - numbers shrink toward zero by halving the distance and booleans toward false,
+//   - strings shrink by removing chunks of runes and then by shrinking a rune toward the first letter,
+//   - slices and maps shrink by removing chunks of elements and then by shrinking an element,
+//   - arrays and structs shrink by shrinking an element or a field, and
+//   - pointers and interfaces shrink by shrinking what they point to or hold.
+//
+// The candidates respect the hints of the struct tags like NewReflect, see Tag. Numbers shrink toward the
+// value of their range which is closest to zero, lengths are not shrunk below the minimum length, and a
+// value of an enumeration only shrinks to the values which precede it in the enumeration.
+// It panics if a tag is invalid.
+func ShrinkReflect(value reflect.Value) iter.Seq[reflect.Value] {
+	return shrink(value, defaultHints)
+}
+
+func shrink(value reflect.Value, hints hints) iter.Seq[reflect.Value] {
+	return func(yield func(reflect.Value) bool) {
+		if len(hints.enum) > 0 {
+			switch value.Kind() {
+			case reflect.Array, reflect.Slice, reflect.Map, reflect.Ptr, reflect.Struct, reflect.Interface:
+			default:
+				for _, str := range hints.enum {
+					candidate := reflect.New(value.Type()).Elem()
+					if err := setString(candidate, str); err != nil {
+						panic(fmt.Errorf("quick: invalid enumeration of %s: %w", value.Type(), err))
+					}
+					// Only the values preceding the value are smaller.
+					if candidate.Equal(value) || !yield(candidate) {
+						return
+					}
+				}
+				return
+			}
+		}
+
+		switch kind := value.Kind(); kind {
+		case reflect.Bool:
+			if value.Bool() {
+				yield(reflect.Zero(value.Type()))
+			}
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			lower, upper, err := hints.ints(value.Type())
+			if err != nil {
+				panic(fmt.Errorf("quick: invalid range of %s: %w", value.Type(), err))
+			}
+			x, target := value.Int(), min(max(0, lower), upper)
+			for distance := x - target; distance != 0; distance /= 2 {
+				if !yieldWith(yield, value.Type(), func(candidate reflect.Value) {
+					candidate.SetInt(x - distance)
+				}) {
+					return
+				}
+			}
+			// Positive numbers are smaller than negative numbers of the same magnitude.
+			if x < 0 && x != math.MinInt64 && -x <= upper {
+				yieldWith(yield, value.Type(), func(candidate reflect.Value) {
+					candidate.SetInt(-x)
+				})
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			lower, _, err := hints.uints(value.Type())
+			if err != nil {
+				panic(fmt.Errorf("quick: invalid range of %s: %w", value.Type(), err))
+			}
+			x := value.Uint()
+			if x < lower {
+				yieldWith(yield, value.Type(), func(candidate reflect.Value) {
+					candidate.SetUint(lower)
+				})
+				return
+			}
+			for distance := x - lower; distance != 0; distance /= 2 {
+				if !yieldWith(yield, value.Type(), func(candidate reflect.Value) {
+					candidate.SetUint(x - distance)
+				}) {
+					return
+				}
+			}
+		case reflect.Float32, reflect.Float64:
+			lower, upper, err := hints.floats(value.Type())
+			if err != nil {
+				panic(fmt.Errorf("quick: invalid range of %s: %w", value.Type(), err))
+			}
+			x, target := value.Float(), min(max(0, lower), upper)
+			if x == target {
+				return
+			}
+			candidates := []float64{target}
+			if !math.IsNaN(x) && !math.IsInf(x, 0) {
+				if truncated := math.Trunc(x); truncated != x && lower <= truncated && truncated <= upper {
+					candidates = append(candidates, truncated)
+				}
+				if halved := target + (x-target)/2; halved != x {
+					candidates = append(candidates, halved)
+				}
+			}
+			for _, candidate := range candidates {
+				if !yieldWith(yield, value.Type(), func(value reflect.Value) {
+					value.SetFloat(candidate)
+				}) {
+					return
+				}
+			}
+		case reflect.Complex64, reflect.Complex128:
+			if value.Complex() != 0 {
+				yield(reflect.Zero(value.Type()))
+			}
+		case reflect.String:
+			runes := []rune(value.String())
+			for candidate := range shrink(reflect.ValueOf(runes), hints.letters()) {
+				if !yieldWith(yield, value.Type(), func(value reflect.Value) {
+					value.SetString(string(candidate.Interface().([]rune)))
+				}) {
+					return
+				}
+			}
+		case reflect.Slice:
+			length := value.Len()
+			// Remove chunks of decreasing sizes starting with all elements allowed by the length.
+			for size := length - hints.minLength; size > 0; size /= 2 {
+				for start := 0; start+size <= length; start += size {
+					candidate := reflect.MakeSlice(value.Type(), 0, length-size)
+					candidate = reflect.AppendSlice(candidate, value.Slice(0, start))
+					candidate = reflect.AppendSlice(candidate, value.Slice(start+size, length))
+					if !yield(addressable(candidate)) {
+						return
+					}
+				}
+			}
+			for i := 0; i < length; i++ {
+				for element := range shrink(value.Index(i), hints.elements()) {
+					candidate := reflect.MakeSlice(value.Type(), length, length)
+					reflect.Copy(candidate, value)
+					candidate.Index(i).Set(element)
+					if !yield(addressable(candidate)) {
+						return
+					}
+				}
+			}
+		case reflect.Map:
+			// The keys are sorted such that the candidates are the same for equal maps.
+			keys := value.MapKeys()
+			slices.SortFunc(keys, func(a, b reflect.Value) int {
+				return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+			})
+			without := func(start, end int) reflect.Value {
+				candidate := reflect.MakeMapWithSize(value.Type(), len(keys)-(end-start))
+				for i, key := range keys {
+					if i < start || end <= i {
+						candidate.SetMapIndex(key, value.MapIndex(key))
+					}
+				}
+				return addressable(candidate)
+			}
+			for size := len(keys) - hints.minLength; size > 0; size /= 2 {
+				for start := 0; start+size <= len(keys); start += size {
+					if !yield(without(start, start+size)) {
+						return
+					}
+				}
+			}
+			// The keys are only shrunk to keys which are not in the map.
+			for i, key := range keys {
+				for shrunk := range shrink(key, hints.elements()) {
+					if value.MapIndex(shrunk).IsValid() {
+						continue
+					}
+					candidate := without(i, i+1)
+					candidate.SetMapIndex(shrunk, value.MapIndex(key))
+					if !yield(candidate) {
+						return
+					}
+				}
+			}
+			for _, key := range keys {
+				for element := range shrink(value.MapIndex(key), hints.elements()) {
+					candidate := without(0, 0)
+					candidate.SetMapIndex(key, element)
+					if !yield(candidate) {
+						return
+					}
+				}
+			}
+		case reflect.Array:
+			value = addressable(value)
+			for i := 0; i < value.Len(); i++ {
+				for element := range shrink(value.Index(i), hints.elements()) {
+					candidate := addressable(value)
+					candidate.Index(i).Set(element)
+					if !yield(candidate) {
+						return
+					}
+				}
+			}
+		case reflect.Struct:
+			value = addressable(value)
+			for i := 0; i < value.NumField(); i++ {
+				hints, err := parseHints(value.Type().Field(i).Tag.Get(Tag))
+				if err != nil {
+					panic(fmt.Errorf("quick: invalid tag of %s.%s: %w", value.Type(), value.Type().Field(i).Name, err))
+				}
+
+				for field := range shrink(settable(value.Field(i)), hints) {
+					candidate := addressable(value)
+					settable(candidate.Field(i)).Set(field)
+					if !yield(candidate) {
+						return
+					}
+				}
+			}
+		case reflect.Interface:
+			if value.IsNil() {
+				return
+			}
+			for element := range shrink(addressable(value.Elem()), hints) {
+				candidate := reflect.New(value.Type()).Elem()
+				candidate.Set(element)
+				if !yield(candidate) {
+					return
+				}
+			}
+		case reflect.Ptr:
+			if value.IsNil() {
+				return
+			}
+			for element := range shrink(value.Elem(), hints) {
+				candidate := reflect.New(value.Type().Elem())
+				candidate.Elem().Set(element)
+				if !yield(addressable(candidate)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Yields a new value of the type after setting it.
+func yieldWith(yield func(reflect.Value) bool, typ reflect.Type, set func(candidate reflect.Value)) bool {
+	candidate := reflect.New(typ).Elem()
+	set(candidate)
+	return yield(candidate)
+}
+
+// A settable copy of the value.
+func addressable(value reflect.Value) reflect.Value {
+	copy := reflect.New(value.Type()).Elem()
+	copy.Set(settable(value))
+	return copy
+}
+
+// The value itself if it is settable and otherwise the same value made settable if it is addressable
+// such that unexported fields can be read and written like in NewReflect.
+func settable(value reflect.Value) reflect.Value {
+	if value.CanSet() || !value.CanAddr() {
+		return value
+	}
+	return reflect.NewAt(value.Type(), unsafe.Pointer(value.UnsafeAddr())).Elem()
+}
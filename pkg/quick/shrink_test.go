@@ -0,0 +1,159 @@
+package quick
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type shrinkable struct {
+	Flag    bool
+	Number  int
+	Bytes   [4]byte
+	Values  []uint16
+	Pointer *float64
+	hidden  int8
+}
+
+type tagged struct {
+	Number int      `quick:"min=10,max=99"`
+	Level  string   `quick:"enum=low|mid|high"`
+	Digits []uint8  `quick:"len=2..4,enum=1|3|5"`
+	Name   string   `quick:"len=3..9"`
+	Ratio  float64  `quick:"min=-8,max=-2"`
+	Flags  []bool   `quick:"len=1..3"`
+	Limits [2]int16 `quick:"min=-5,max=5"`
+}
+
+func TestShrink(t *testing.T) {
+	pi := 3.14
+
+	tests := []struct {
+		description string
+		value       any
+		fails       func(value any) bool
+		shrunk      any
+	}{
+		{
+			description: "positive integer",
+			value:       1000,
+			fails:       func(value any) bool { return value.(int) >= 17 },
+			shrunk:      17,
+		},
+		{
+			description: "negative integer",
+			value:       -1000,
+			fails:       func(value any) bool { return value.(int) <= -17 },
+			shrunk:      -17,
+		},
+		{
+			description: "integer of any sign",
+			value:       int8(-100),
+			fails:       func(value any) bool { return value.(int8) != 0 },
+			shrunk:      int8(1),
+		},
+		{
+			description: "unsigned integer",
+			value:       uint64(1 << 63),
+			fails:       func(value any) bool { return value.(uint64) > 5 },
+			shrunk:      uint64(6),
+		},
+		{
+			description: "float",
+			value:       123.456,
+			fails:       func(value any) bool { return value.(float64) > 1 },
+			shrunk:      1.5,
+		},
+		{
+			description: "slice",
+			value:       []int{5, 8, 13, 21, 34},
+			fails:       func(value any) bool { return slices.Contains(value.([]int), 13) },
+			shrunk:      []int{13},
+		},
+		{
+			description: "array",
+			value:       [3]int{5, 8, 13},
+			fails:       func(value any) bool { return value.([3]int)[1] >= 2 },
+			shrunk:      [3]int{0, 2, 0},
+		},
+		{
+			description: "struct",
+			value: shrinkable{
+				Flag: true, Number: 42, Bytes: [4]byte{9, 9, 9, 9}, Values: []uint16{1, 2, 3}, Pointer: &pi, hidden: 7,
+			},
+			fails: func(value any) bool {
+				return value.(shrinkable).Bytes[2] > 3 && value.(shrinkable).hidden != 0
+			},
+			shrunk: shrinkable{Bytes: [4]byte{0, 0, 4, 0}, Values: []uint16{}, Pointer: new(float64), hidden: 1},
+		},
+		{
+			description: "string",
+			value:       "gorrupt",
+			fails:       func(value any) bool { return strings.Contains(value.(string), "r") },
+			shrunk:      "r",
+		},
+		{
+			description: "string of letters",
+			value:       "xyz",
+			fails:       func(value any) bool { return len(value.(string)) >= 2 && value.(string)[1] > 'k' },
+			shrunk:      "al",
+		},
+		{
+			description: "map",
+			value:       map[string]int{"a": 1, "b": 20, "c": 3},
+			fails:       func(value any) bool { return value.(map[string]int)["b"] > 7 },
+			shrunk:      map[string]int{"b": 8},
+		},
+		{
+			description: "map keys",
+			value:       map[int]bool{40: true, 50: false},
+			fails:       func(value any) bool { return len(value.(map[int]bool)) == 2 },
+			shrunk:      map[int]bool{0: false, 1: false},
+		},
+		{
+			description: "tagged struct",
+			value: tagged{
+				Number: 80, Level: "high", Digits: []uint8{5, 3, 5, 1}, Name: "gorrupt", Ratio: -7.5,
+				Flags: []bool{true, true}, Limits: [2]int16{-4, 4},
+			},
+			fails: func(value any) bool {
+				tagged := value.(tagged)
+				return tagged.Level != "low" && slices.Contains(tagged.Digits, 5) && tagged.Limits[1] > 2
+			},
+			shrunk: tagged{
+				Number: 10, Level: "mid", Digits: []uint8{5, 1}, Name: "aaa", Ratio: -2,
+				Flags: []bool{false}, Limits: [2]int16{0, 3},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			assert.True(t, tt.fails(tt.value))
+			original := reflect.ValueOf(tt.value).String()
+
+			shrunk, err := Shrink(tt.value, func(value any) (bool, error) {
+				return tt.fails(value), nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.shrunk, shrunk)
+			assert.Equal(t, original, reflect.ValueOf(tt.value).String())
+		})
+	}
+}
+
+func TestShrinkError(t *testing.T) {
+	failure := errors.New("failure")
+	shrunk, err := Shrink(10, func(value int) (bool, error) {
+		if value < 5 {
+			return false, failure
+		}
+		return true, nil
+	})
+	assert.ErrorIs(t, err, failure)
+	assert.Equal(t, 10, shrunk)
+}
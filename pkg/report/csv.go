@@ -0,0 +1,37 @@
+package report
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+var header = []string{"plan", "outcome", "attack", "model", "pc", "function", "source"}
+
+// Writes the findings as CSV with a row for every attack of every finding.
+func WriteCSV(writer io.Writer, findings []Finding) error {
+	csv := csv.NewWriter(writer)
+	if err := csv.Write(header); err != nil {
+		return err
+	}
+
+	for _, finding := range findings {
+		for _, attack := range finding.Attacks {
+			row := []string{
+				finding.Plan,
+				finding.Outcome.String(),
+				attack.Attack,
+				attack.Model,
+				fmt.Sprintf("0x%x", attack.PC),
+				attack.Function,
+				attack.Source,
+			}
+			if err := csv.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+
+	csv.Flush()
+	return csv.Error()
+}
@@ -0,0 +1,284 @@
+package report
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"fmt"
+	"html/template"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/hyperproperties/gorrupt/pkg/fi/tester"
+)
+
+// The number of attacks of each outcome.
+type Counts map[tester.Outcome]int
+
+// The attacks which silently corrupted the output.
+func (counts Counts) Undetected() int {
+	return counts[tester.CorruptionOutcome]
+}
+
+func (counts Counts) Detected() int {
+	return counts[tester.DetectedOutcome]
+}
+
+// The attacks which crashed the binary, made it time out, or made its output unparsable.
+func (counts Counts) Crashed() int {
+	return counts[tester.CrashOutcome] + counts[tester.TimeoutOutcome] + counts[tester.UnparsableOutcome]
+}
+
+func (counts Counts) Total() (total int) {
+	for _, count := range counts {
+		total += count
+	}
+	return
+}
+
+// A line of a source file.
+type Line struct {
+	Path   string
+	Number int
+}
+
+// The outcomes of a campaign aggregated per source line and per function.
+type Heatmap struct {
+	lines     map[Line]Counts
+	functions map[string]Counts
+}
+
+// Aggregates the outcomes of the findings. An attack plan counts towards the location of each of its attacks.
+func NewHeatmap(findings []Finding) Heatmap {
+	heatmap := Heatmap{
+		lines:     make(map[Line]Counts),
+		functions: make(map[string]Counts),
+	}
+
+	for _, finding := range findings {
+		for _, attack := range finding.Attacks {
+			if line := (Line{attack.Path(), attack.Line()}); line.Path != "" {
+				if heatmap.lines[line] == nil {
+					heatmap.lines[line] = make(Counts)
+				}
+				heatmap.lines[line][finding.Outcome]++
+			}
+
+			if attack.Function != "" {
+				if heatmap.functions[attack.Function] == nil {
+					heatmap.functions[attack.Function] = make(Counts)
+				}
+				heatmap.functions[attack.Function][finding.Outcome]++
+			}
+		}
+	}
+
+	return heatmap
+}
+
+// The counts of the line. They are empty if the line was not attacked.
+func (heatmap Heatmap) Line(path string, number int) Counts {
+	return heatmap.lines[Line{path, number}]
+}
+
+func (heatmap Heatmap) Function(name string) Counts {
+	return heatmap.functions[name]
+}
+
+// The attacked lines sorted by path and number.
+func (heatmap Heatmap) Lines() []Line {
+	return slices.SortedFunc(maps.Keys(heatmap.lines), func(a, b Line) int {
+		return cmp.Or(cmp.Compare(a.Path, b.Path), cmp.Compare(a.Number, b.Number))
+	})
+}
+
+// The attacked functions sorted by the number of undetected attacks (most first) and then name.
+func (heatmap Heatmap) Functions() []string {
+	return slices.SortedFunc(maps.Keys(heatmap.functions), func(a, b string) int {
+		return cmp.Or(
+			cmp.Compare(heatmap.functions[b].Undetected(), heatmap.functions[a].Undetected()),
+			cmp.Compare(a, b),
+		)
+	})
+}
+
+// The attacked files sorted by path.
+func (heatmap Heatmap) Files() []string {
+	var files []string
+	for _, line := range heatmap.Lines() {
+		if len(files) == 0 || files[len(files)-1] != line.Path {
+			files = append(files, line.Path)
+		}
+	}
+	return files
+}
+
+// An annotated line of a source listing.
+type listing struct {
+	Number int
+	Code   string
+	Counts Counts
+	// The share of undetected attacks of the line compared to the line with most (0 to 1).
+	Heat float64
+}
+
+// The annotated lines of the file. The file is read from the path and an error is returned if it cannot be.
+func (heatmap Heatmap) listing(path string) ([]listing, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	maximum := 0
+	for line, counts := range heatmap.lines {
+		if line.Path == path {
+			maximum = max(maximum, counts.Undetected())
+		}
+	}
+
+	var lines []listing
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for number := 1; scanner.Scan(); number++ {
+		line := listing{
+			Number: number,
+			Code:   strings.ReplaceAll(scanner.Text(), "\t", "    "),
+			Counts: heatmap.Line(path, number),
+		}
+		if maximum > 0 {
+			line.Heat = float64(line.Counts.Undetected()) / float64(maximum)
+		}
+		lines = append(lines, line)
+	}
+
+	return lines, scanner.Err()
+}
+
+const (
+	red    = "\x1b[31m"
+	yellow = "\x1b[33m"
+	reset  = "\x1b[0m"
+)
+
+// Writes the counts per function followed by the annotated listing of each attacked file which can be read.
+// The columns are the undetected, detected, and crashed attacks. If colored then lines with undetected attacks
+// are red and lines with crashes yellow using ANSI escape codes.
+func (heatmap Heatmap) WriteTerminal(writer io.Writer, colored bool) error {
+	var buffer bytes.Buffer
+
+	fmt.Fprintf(&buffer, "%10s %10s %10s  %s\n", "undetected", "detected", "crashed", "function")
+	for _, function := range heatmap.Functions() {
+		counts := heatmap.Function(function)
+		fmt.Fprintf(&buffer, "%10d %10d %10d  %s\n", counts.Undetected(), counts.Detected(), counts.Crashed(), function)
+	}
+
+	for _, file := range heatmap.Files() {
+		lines, err := heatmap.listing(file)
+		if err != nil {
+			// Files inlined from other packages are only known by their name.
+			continue
+		}
+
+		fmt.Fprintf(&buffer, "\n%s\n", file)
+		for _, line := range lines {
+			color := ""
+			switch {
+			case !colored:
+			case line.Counts.Undetected() > 0:
+				color = red
+			case line.Counts.Crashed() > 0:
+				color = yellow
+			}
+
+			counts := strings.Repeat(" ", 3*6)
+			if line.Counts.Total() > 0 {
+				counts = fmt.Sprintf("%5d %5d %5d ", line.Counts.Undetected(), line.Counts.Detected(), line.Counts.Crashed())
+			}
+
+			if color != "" {
+				fmt.Fprintf(&buffer, "%s%s%5d | %s%s\n", color, counts, line.Number, line.Code, reset)
+			} else {
+				fmt.Fprintf(&buffer, "%s%5d | %s\n", counts, line.Number, line.Code)
+			}
+		}
+	}
+
+	_, err := buffer.WriteTo(writer)
+	return err
+}
+
+type htmlFile struct {
+	Path  string
+	Lines []listing
+}
+
+var heatmapTemplate = template.Must(template.New("heatmap").Funcs(template.FuncMap{
+	"background": func(heat float64) template.CSS {
+		if heat == 0 {
+			return ""
+		}
+		return template.CSS(fmt.Sprintf("background: rgba(220, 40, 40, %.2f)", 0.15+0.6*heat))
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>gorrupt heatmap</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; }
+td, th { padding: 0 0.5em; text-align: right; }
+td.code { text-align: left; white-space: pre; font-family: monospace; }
+tr.crashed td.code { background: rgba(230, 180, 30, 0.3); }
+</style>
+</head>
+<body>
+<h1>Functions</h1>
+<table>
+<tr><th>undetected</th><th>detected</th><th>crashed</th><th>function</th></tr>
+{{- range .Functions}}
+<tr><td>{{.Counts.Undetected}}</td><td>{{.Counts.Detected}}</td><td>{{.Counts.Crashed}}</td><td class="code">{{.Name}}</td></tr>
+{{- end}}
+</table>
+{{- range .Files}}
+<h2>{{.Path}}</h2>
+<table>
+<tr><th>undetected</th><th>detected</th><th>crashed</th><th>line</th><th></th></tr>
+{{- range .Lines}}
+<tr{{if and (eq .Counts.Undetected 0) (gt .Counts.Crashed 0)}} class="crashed"{{end}}>
+{{- if .Counts.Total}}<td>{{.Counts.Undetected}}</td><td>{{.Counts.Detected}}</td><td>{{.Counts.Crashed}}</td>{{else}}<td></td><td></td><td></td>{{end -}}
+<td>{{.Number}}</td><td class="code" style="{{background .Heat}}">{{.Code}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+</body>
+</html>
+`))
+
+// Writes the counts per function and the annotated listing of each attacked file which can be read as an HTML page.
+// The lines are shaded by their number of undetected attacks.
+func (heatmap Heatmap) WriteHTML(writer io.Writer) error {
+	type function struct {
+		Name   string
+		Counts Counts
+	}
+
+	var data struct {
+		Functions []function
+		Files     []htmlFile
+	}
+
+	for _, name := range heatmap.Functions() {
+		data.Functions = append(data.Functions, function{name, heatmap.Function(name)})
+	}
+
+	for _, file := range heatmap.Files() {
+		if lines, err := heatmap.listing(file); err == nil {
+			data.Files = append(data.Files, htmlFile{file, lines})
+		}
+	}
+
+	return heatmapTemplate.Execute(writer, data)
+}
@@ -0,0 +1,95 @@
+package report
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/hyperproperties/gorrupt/pkg/fi"
+	"github.com/hyperproperties/gorrupt/pkg/fi/tester"
+	"github.com/hyperproperties/gorrupt/pkg/obj"
+	"github.com/stretchr/testify/assert"
+)
+
+func heatmapFixture(t *testing.T) (string, []Finding) {
+	file := path.Join(t.TempDir(), "verify_pin.go")
+	source := "package pkg\n\nfunc VerifyPIN() bool {\n\treturn ptc > 0\n}\n"
+	assert.NoError(t, os.WriteFile(file, []byte(source), 0644))
+
+	dump := obj.New(
+		obj.NewFunction("TEXT", "pkg.VerifyPIN(SB)", file,
+			obj.NewInstruction("verify_pin.go:3", 0x1000, 0xe15d0001, "CMP R1, R13"),
+			obj.NewInstruction("verify_pin.go:4", 0x1004, 0xe3a00003, "MOVW $3, R0"),
+		),
+	)
+
+	findings := []Finding{
+		NewFinding(dump, fi.AttackPlan{fi.NewIS(0x1004, 0)}, tester.CorruptionOutcome),
+		NewFinding(dump, fi.AttackPlan{fi.NewIC(0x1004, 1, 0)}, tester.CorruptionOutcome),
+		NewFinding(dump, fi.AttackPlan{fi.NewIS(0x1000, 0)}, tester.DetectedOutcome),
+		NewFinding(dump, fi.AttackPlan{fi.NewIC(0x1000, 1, 0)}, tester.CrashOutcome),
+		NewFinding(dump, fi.AttackPlan{fi.NewIC(0x1000, 2, 0)}, tester.GoldenOutcome),
+	}
+
+	return file, findings
+}
+
+func TestHeatmap(t *testing.T) {
+	file, findings := heatmapFixture(t)
+	heatmap := NewHeatmap(findings)
+
+	assert.Equal(t, []Line{{file, 3}, {file, 4}}, heatmap.Lines())
+	assert.Equal(t, []string{file}, heatmap.Files())
+	assert.Equal(t, []string{"pkg.VerifyPIN(SB)"}, heatmap.Functions())
+
+	line := heatmap.Line(file, 3)
+	assert.Equal(t, 0, line.Undetected())
+	assert.Equal(t, 1, line.Detected())
+	assert.Equal(t, 1, line.Crashed())
+	assert.Equal(t, 3, line.Total())
+	assert.Equal(t, 2, heatmap.Line(file, 4).Undetected())
+	assert.Empty(t, heatmap.Line(file, 1))
+
+	function := heatmap.Function("pkg.VerifyPIN(SB)")
+	assert.Equal(t, 2, function.Undetected())
+	assert.Equal(t, 5, function.Total())
+}
+
+func TestHeatmapWriteTerminal(t *testing.T) {
+	file, findings := heatmapFixture(t)
+
+	var buffer bytes.Buffer
+	assert.NoError(t, NewHeatmap(findings).WriteTerminal(&buffer, false))
+	assert.Equal(t, ""+
+		"undetected   detected    crashed  function\n"+
+		"         2          1          1  pkg.VerifyPIN(SB)\n"+
+		"\n"+
+		file+"\n"+
+		"                      1 | package pkg\n"+
+		"                      2 | \n"+
+		"    0     1     1     3 | func VerifyPIN() bool {\n"+
+		"    2     0     0     4 |     return ptc > 0\n"+
+		"                      5 | }\n",
+		buffer.String())
+
+	buffer.Reset()
+	assert.NoError(t, NewHeatmap(findings).WriteTerminal(&buffer, true))
+	assert.Contains(t, buffer.String(), red+"    2     0     0     4 |     return ptc > 0"+reset)
+	assert.Contains(t, buffer.String(), yellow+"    0     1     1     3 |")
+}
+
+func TestHeatmapWriteHTML(t *testing.T) {
+	_, findings := heatmapFixture(t)
+
+	var buffer bytes.Buffer
+	assert.NoError(t, NewHeatmap(findings).WriteHTML(&buffer))
+
+	html := buffer.String()
+	assert.True(t, strings.HasPrefix(html, "<!DOCTYPE html>"))
+	assert.Contains(t, html, "<td>2</td><td>1</td><td>1</td><td class=\"code\">pkg.VerifyPIN(SB)</td>")
+	assert.Contains(t, html, "<tr class=\"crashed\"><td>0</td><td>1</td><td>1</td><td>3</td>")
+	assert.Contains(t, html, "background: rgba(220, 40, 40, 0.75)")
+	assert.Contains(t, html, "return ptc &gt; 0")
+}
@@ -0,0 +1,17 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// Writes the findings as JSON Lines, i.e., one json encoded finding per line.
+func WriteJSONLines(writer io.Writer, findings []Finding) error {
+	encoder := json.NewEncoder(writer)
+	for _, finding := range findings {
+		if err := encoder.Encode(finding); err != nil {
+			return err
+		}
+	}
+	return nil
+}
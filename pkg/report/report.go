@@ -0,0 +1,172 @@
+package report
+
+import (
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hyperproperties/gorrupt/pkg/fi"
+	"github.com/hyperproperties/gorrupt/pkg/fi/tester"
+	"github.com/hyperproperties/gorrupt/pkg/obj"
+)
+
+// A finding is an attack plan and the outcome of executing the binary under it.
+type Finding struct {
+	Plan    string         `json:"plan"`
+	Outcome tester.Outcome `json:"outcome"`
+	// The json encoded input (if known).
+	Input   json.RawMessage `json:"input,omitempty"`
+	Attacks []Attack        `json:"attacks"`
+}
+
+// An attack of a finding and where in the binary it was injected.
+type Attack struct {
+	Attack string `json:"attack"`
+	// The fault model, e.g., "bfr" or "is".
+	Model string `json:"model"`
+	PC    uint64 `json:"pc"`
+	// The qualified name of the function containing the PC, e.g., "pkg.VerifyPIN(SB)".
+	Function string `json:"function,omitempty"`
+	// The file of the function, e.g., "/home/user/pkg/verify_pin.go".
+	File string `json:"file,omitempty"`
+	// The source of the instruction at the PC, e.g., "verify_pin.go:62".
+	Source string `json:"source,omitempty"`
+}
+
+// The line of the source or "0" if unknown.
+func (attack Attack) Line() int {
+	idx := strings.LastIndex(attack.Source, ":")
+	line, err := strconv.Atoi(attack.Source[idx+1:])
+	if err != nil || line < 0 {
+		return 0
+	}
+	return line
+}
+
+// The path of the source file. It is the file of the function unless the instruction is inlined from another file.
+func (attack Attack) Path() string {
+	name := attack.Source
+	if idx := strings.LastIndex(name, ":"); idx >= 0 {
+		name = name[:idx]
+	}
+
+	if name == "" || strings.HasSuffix(attack.File, "/"+name) || attack.File == name {
+		return attack.File
+	}
+	return name
+}
+
+// Locates the attacks of the plan in the dump.
+func NewFinding(dump obj.Dump, plan fi.AttackPlan, outcome tester.Outcome) Finding {
+	finding := Finding{
+		Plan:    plan.String(),
+		Outcome: outcome,
+		Attacks: make([]Attack, len(plan)),
+	}
+
+	for idx, attack := range plan {
+		finding.Attacks[idx] = locate(dump, attack)
+	}
+
+	return finding
+}
+
+func FromExecution[In, Out any](dump obj.Dump, execution tester.Execution[In, Out]) (Finding, error) {
+	finding := NewFinding(dump, execution.Plan, execution.Outcome)
+
+	var err error
+	finding.Input, err = json.Marshal(execution.Input)
+
+	return finding, err
+}
+
+// The findings of the records of the binary in the store with one of the outcomes. The binary is the hash
+// of the binary of the dump. The records of other binaries are skipped since their PCs are not in the dump.
+// If no outcomes are given then every record except those of the golden executions are included.
+func FromStore(dump obj.Dump, binary string, store *tester.Store, outcomes ...tester.Outcome) ([]Finding, error) {
+	var findings []Finding
+	for record := range store.Records() {
+		if record.Binary != binary {
+			continue
+		}
+
+		plan, err := fi.ParseAttackPlan(record.Plan)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(outcomes) > 0 && !slices.Contains(outcomes, record.Outcome) {
+			continue
+		}
+		if len(outcomes) == 0 && len(plan) == 0 {
+			continue
+		}
+
+		finding := NewFinding(dump, plan, record.Outcome)
+		finding.Input = record.Input
+		findings = append(findings, finding)
+	}
+
+	return findings, nil
+}
+
+func locate(dump obj.Dump, attack fi.Attack) Attack {
+	located := Attack{
+		Attack: attack.String(),
+		Model:  strings.Fields(attack.String())[0],
+	}
+
+	if target, ok := attack.(fi.Target); ok {
+		var locator locator
+		target.Visit(&locator)
+		located.PC = uint64(locator.pc)
+	}
+
+	if function, instruction, ok := dump.Instruction(located.PC); ok {
+		located.Function = function.QualifiedName()
+		located.File = function.Source()
+		located.Source = instruction.Source()
+	}
+
+	return located
+}
+
+var _ fi.TagetVisitor = (*locator)(nil)
+
+// Finds the PC of an attack. For transitions it is the destination
+// (the instruction executed after the fault) unless it is any.
+type locator struct {
+	pc fi.PC
+}
+
+func (locator *locator) transition(transition fi.Transition) {
+	locator.pc = transition.Destination()
+	if locator.pc == 0 {
+		locator.pc = transition.Source()
+	}
+}
+
+func (locator *locator) BFR(bfr fi.BFRTarget) {
+	locator.transition(bfr.Transition)
+}
+
+func (locator *locator) BFM(bfm fi.BFMTarget) {
+	locator.transition(bfm.Transition)
+}
+
+func (locator *locator) SAR(sar fi.SARTarget) {
+	locator.transition(sar.Transition)
+}
+
+func (locator *locator) SR(sr fi.SRTarget) {
+	locator.transition(sr.Transition)
+}
+
+func (locator *locator) IS(is fi.ISTarget) {
+	locator.pc = is.PC()
+}
+
+func (locator *locator) IC(ic fi.ICTarget) {
+	locator.pc = ic.PC()
+}
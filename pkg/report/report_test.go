@@ -0,0 +1,177 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"path"
+	"testing"
+
+	"github.com/hyperproperties/gorrupt/pkg/fi"
+	"github.com/hyperproperties/gorrupt/pkg/fi/tester"
+	"github.com/hyperproperties/gorrupt/pkg/obj"
+	"github.com/stretchr/testify/assert"
+)
+
+var dump = obj.New(
+	obj.NewFunction("TEXT", "pkg.VerifyPIN(SB)", "/home/user/pkg/verify_pin.go",
+		obj.NewInstruction("verify_pin.go:60", 0x1000, 0xe15d0001, "CMP R1, R13"),
+		obj.NewInstruction("verify_pin.go:60", 0x1004, 0x9a000018, "B.LS 0x106c"),
+		obj.NewInstruction("compare.go:8", 0x1008, 0xe3a00003, "MOVW $3, R0"),
+	),
+)
+
+var plan = fi.AttackPlan{
+	fi.NewBFR(1, 0, 0x1000, 0x1004, 0x2),
+	fi.NewIS(0x1008, 0),
+}
+
+func TestNewFinding(t *testing.T) {
+	finding := NewFinding(dump, plan, tester.CorruptionOutcome)
+
+	assert.Equal(t, plan.String(), finding.Plan)
+	assert.Equal(t, []Attack{
+		{
+			Attack:   "bfr 1 0 0x1000 0x1004 2",
+			Model:    "bfr",
+			PC:       0x1004,
+			Function: "pkg.VerifyPIN(SB)",
+			File:     "/home/user/pkg/verify_pin.go",
+			Source:   "verify_pin.go:60",
+		},
+		{
+			Attack:   "is 4104 0",
+			Model:    "is",
+			PC:       0x1008,
+			Function: "pkg.VerifyPIN(SB)",
+			File:     "/home/user/pkg/verify_pin.go",
+			Source:   "compare.go:8",
+		},
+	}, finding.Attacks)
+
+	assert.Equal(t, "/home/user/pkg/verify_pin.go", finding.Attacks[0].Path())
+	assert.Equal(t, 60, finding.Attacks[0].Line())
+	// The instruction is inlined from another file.
+	assert.Equal(t, "compare.go", finding.Attacks[1].Path())
+
+	// Attacks outside of the dump are not located.
+	unknown := NewFinding(dump, fi.AttackPlan{fi.NewIS(0x2000, 0)}, tester.CrashOutcome)
+	assert.Equal(t, []Attack{{Attack: "is 8192 0", Model: "is", PC: 0x2000}}, unknown.Attacks)
+}
+
+func TestWriteJSONLines(t *testing.T) {
+	findings := []Finding{
+		NewFinding(dump, plan, tester.CorruptionOutcome),
+		NewFinding(dump, plan[:1], tester.CrashOutcome),
+	}
+
+	var buffer bytes.Buffer
+	assert.NoError(t, WriteJSONLines(&buffer, findings))
+
+	decoder := json.NewDecoder(&buffer)
+	for _, expected := range findings {
+		var finding Finding
+		assert.NoError(t, decoder.Decode(&finding))
+		assert.Equal(t, expected, finding)
+	}
+	assert.False(t, decoder.More())
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buffer bytes.Buffer
+	assert.NoError(t, WriteCSV(&buffer, []Finding{NewFinding(dump, plan, tester.DetectedOutcome)}))
+	assert.Equal(t, ""+
+		"plan,outcome,attack,model,pc,function,source\n"+
+		"\"[bfr 1 0 0x1000 0x1004 2, is 4104 0]\",detected,bfr 1 0 0x1000 0x1004 2,bfr,0x1004,pkg.VerifyPIN(SB),verify_pin.go:60\n"+
+		"\"[bfr 1 0 0x1000 0x1004 2, is 4104 0]\",detected,is 4104 0,is,0x1008,pkg.VerifyPIN(SB),compare.go:8\n",
+		buffer.String())
+}
+
+func TestWriteSARIF(t *testing.T) {
+	tests := []struct {
+		description string
+		root        string
+		uri         string
+		base        string
+		bases       map[string]sarifArtifactLocation
+	}{
+		{
+			description: "relative to the root",
+			root:        "/home/user",
+			uri:         "pkg/verify_pin.go",
+			base:        "%SRCROOT%",
+			bases:       map[string]sarifArtifactLocation{"%SRCROOT%": {URI: "file:///home/user/"}},
+		},
+		{
+			description: "outside the root",
+			root:        "/home/other/",
+			uri:         "file:///home/user/pkg/verify_pin.go",
+			bases:       map[string]sarifArtifactLocation{"%SRCROOT%": {URI: "file:///home/other/"}},
+		},
+		{
+			description: "no root",
+			uri:         "file:///home/user/pkg/verify_pin.go",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			var buffer bytes.Buffer
+			assert.NoError(t, WriteSARIF(&buffer, tt.root, []Finding{NewFinding(dump, plan, tester.CorruptionOutcome)}))
+
+			var log sarif
+			assert.NoError(t, json.Unmarshal(buffer.Bytes(), &log))
+			assert.Equal(t, "2.1.0", log.Version)
+			assert.Len(t, log.Runs, 1)
+			assert.Len(t, log.Runs[0].Tool.Driver.Rules, 7)
+			assert.Equal(t, tt.bases, log.Runs[0].OriginalURIBaseIDs)
+
+			results := log.Runs[0].Results
+			assert.Len(t, results, 1)
+			assert.Equal(t, "corruption", results[0].RuleID)
+			assert.Equal(t, "error", results[0].Level)
+			assert.Len(t, results[0].Locations, 2)
+			location := results[0].Locations[0].PhysicalLocation
+			assert.Equal(t, tt.uri, location.ArtifactLocation.URI)
+			assert.Equal(t, tt.base, location.ArtifactLocation.URIBaseID)
+			assert.Equal(t, &sarifRegion{60}, location.Region)
+			assert.Equal(t, "pkg.VerifyPIN(SB)", results[0].Locations[0].LogicalLocations[0].FullyQualifiedName)
+		})
+	}
+}
+
+func TestFromStore(t *testing.T) {
+	store, err := tester.OpenStore(path.Join(t.TempDir(), tester.StoreName))
+	assert.NoError(t, err)
+	defer store.Close()
+
+	executions := []tester.Execution[int, int]{
+		{Input: 1, Plan: fi.AttackPlan{}, Outcome: tester.GoldenOutcome},
+		{Input: 1, Plan: plan[:1], Outcome: tester.GoldenOutcome},
+		{Input: 1, Plan: plan, Output: 2, Outcome: tester.CorruptionOutcome},
+	}
+	for _, execution := range executions {
+		record, err := tester.NewRecord("abc", execution)
+		assert.NoError(t, err)
+		assert.NoError(t, store.Add(record))
+	}
+
+	// The records of another binary, e.g., an older build, are not located in the dump.
+	stale, err := tester.NewRecord("def", tester.Execution[int, int]{Input: 1, Plan: plan, Output: 3, Outcome: tester.CorruptionOutcome})
+	assert.NoError(t, err)
+	assert.NoError(t, store.Add(stale))
+
+	findings, err := FromStore(dump, "abc", store)
+	assert.NoError(t, err)
+	assert.Len(t, findings, 2)
+	assert.Equal(t, json.RawMessage("1"), findings[0].Input)
+
+	findings, err = FromStore(dump, "abc", store, tester.CorruptionOutcome)
+	assert.NoError(t, err)
+	assert.Len(t, findings, 1)
+	assert.Equal(t, plan.String(), findings[0].Plan)
+
+	findings, err = FromStore(dump, "def", store, tester.CorruptionOutcome)
+	assert.NoError(t, err)
+	assert.Len(t, findings, 1)
+	assert.Equal(t, json.RawMessage("1"), findings[0].Input)
+}
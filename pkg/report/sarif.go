@@ -0,0 +1,184 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/hyperproperties/gorrupt/pkg/fi/tester"
+)
+
+// The static analysis results interchange format (SARIF) version 2.1.0.
+// Only the parts needed for reporting findings are modelled.
+// https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+type sarif struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool               sarifTool                        `json:"tool"`
+	OriginalURIBaseIDs map[string]sarifArtifactLocation `json:"originalUriBaseIds,omitempty"`
+	Results            []sarifResult                    `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID     string            `json:"ruleId"`
+	Level      string            `json:"level"`
+	Message    sarifMessage      `json:"message"`
+	Locations  []sarifLocation   `json:"locations,omitempty"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+// The base of the URIs relative to the root of the source, e.g., of the module or repository.
+const sarifSourceRoot = "%SRCROOT%"
+
+// The location of the file. It is relative to the root if it is inside it and else an absolute file URI.
+func artifact(root, file string) sarifArtifactLocation {
+	if root != "" {
+		if relative, err := filepath.Rel(root, file); err == nil && filepath.IsLocal(relative) {
+			uri := url.URL{Path: filepath.ToSlash(relative)}
+			return sarifArtifactLocation{URI: uri.String(), URIBaseID: sarifSourceRoot}
+		}
+	}
+
+	uri := url.URL{Scheme: "file", Path: filepath.ToSlash(file)}
+	return sarifArtifactLocation{URI: uri.String()}
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// The rule of each outcome and the level of its results.
+var rules = []struct {
+	outcome     tester.Outcome
+	level       string
+	description string
+}{
+	{tester.GoldenOutcome, "none", "The fault was masked and the output is equal to the golden output."},
+	{tester.CorruptionOutcome, "error", "The fault silently corrupted the output."},
+	{tester.DetectedOutcome, "note", "The fault was detected by a countermeasure."},
+	{tester.CrashOutcome, "warning", "The fault crashed the binary."},
+	{tester.TimeoutOutcome, "warning", "The fault caused the binary to time out."},
+	{tester.EmulatorOutcome, "note", "The emulator failed under the fault."},
+	{tester.UnparsableOutcome, "warning", "The fault made the output unparsable."},
+}
+
+// Writes the findings as a SARIF log with a result for every finding. The rule of a result is its outcome
+// and its locations are those of its attacks. The locations in the root directory of the source (if any),
+// e.g., the module, are relative to it such that code scanning resolves them in the repository.
+func WriteSARIF(writer io.Writer, root string, findings []Finding) error {
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           "gorrupt",
+				InformationURI: "https://github.com/hyperproperties/gorrupt",
+			},
+		},
+		Results: make([]sarifResult, 0, len(findings)),
+	}
+	if root != "" {
+		uri := url.URL{Scheme: "file", Path: strings.TrimSuffix(filepath.ToSlash(root), "/") + "/"}
+		run.OriginalURIBaseIDs = map[string]sarifArtifactLocation{
+			sarifSourceRoot: {URI: uri.String()},
+		}
+	}
+
+	levels := make(map[tester.Outcome]string, len(rules))
+	for _, rule := range rules {
+		levels[rule.outcome] = rule.level
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+			ID:               rule.outcome.String(),
+			ShortDescription: sarifMessage{rule.description},
+		})
+	}
+
+	for _, finding := range findings {
+		result := sarifResult{
+			RuleID:  finding.Outcome.String(),
+			Level:   levels[finding.Outcome],
+			Message: sarifMessage{fmt.Sprintf("The attack plan %s resulted in %s.", finding.Plan, finding.Outcome)},
+			Properties: map[string]string{
+				"plan": finding.Plan,
+			},
+		}
+		if len(finding.Input) > 0 {
+			result.Properties["input"] = string(finding.Input)
+		}
+
+		for _, attack := range finding.Attacks {
+			if attack.Path() == "" {
+				continue
+			}
+
+			location := sarifLocation{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: artifact(root, attack.Path()),
+				},
+			}
+			if line := attack.Line(); line > 0 {
+				location.PhysicalLocation.Region = &sarifRegion{line}
+			}
+			if attack.Function != "" {
+				location.LogicalLocations = []sarifLogicalLocation{
+					{FullyQualifiedName: attack.Function, Kind: "function"},
+				}
+			}
+			result.Locations = append(result.Locations, location)
+		}
+
+		run.Results = append(run.Results, result)
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarif{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	})
+}